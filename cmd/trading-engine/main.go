@@ -11,6 +11,7 @@ import (
 
 	"trading-system/internal/config"
 	"trading-system/internal/database"
+	"trading-system/internal/logging"
 	"trading-system/internal/server"
 	"trading-system/internal/signals"
 )
@@ -22,6 +23,10 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Initialize structured logging (see internal/logging); the level can
+	// be hot-reloaded at runtime via POST /admin/log-level.
+	logging.Init(cfg.Logging)
+
 	// Initialize database connection
 	db, err := database.New(&cfg.Database)
 	if err != nil {
@@ -14,8 +14,12 @@ type Config struct {
 	Database    DatabaseConfig
 	Server      ServerConfig
 	MT5         MT5Config
+	Exchanges   ExchangesConfig
 	Risk        RiskConfig
+	Budget      BudgetConfig
+	Instrument  InstrumentConfig
 	Logging     LoggingConfig
+	Aggregator  AggregatorConfig
 	Environment string
 }
 
@@ -28,8 +32,33 @@ type DatabaseConfig struct {
 
 // ServerConfig holds HTTP server settings
 type ServerConfig struct {
-	Port          string
-	WebhookSecret string
+	Port string
+	// WebhookSecret holds the HMAC verification secret for each webhook
+	// source, keyed by source name (e.g. "tradingview", "discord",
+	// "github"). Each source can also have a distinct signature header
+	// name via WebhookHeaderName; it defaults to "X-Signature".
+	WebhookSecret     map[string]string
+	WebhookHeaderName map[string]string
+	// IdempotencyWindowSeconds is how long a content hash is remembered so
+	// retried alerts are treated as duplicates instead of reprocessed.
+	IdempotencyWindowSeconds int
+	// WebhookMaxAgeSeconds rejects signals whose embedded timestamp is
+	// older than this, mitigating replay of captured webhook payloads.
+	WebhookMaxAgeSeconds int
+}
+
+// SecretFor returns the configured HMAC secret for a webhook source.
+func (s *ServerConfig) SecretFor(source string) string {
+	return s.WebhookSecret[source]
+}
+
+// HeaderNameFor returns the configured signature header name for a webhook
+// source, defaulting to "X-Signature".
+func (s *ServerConfig) HeaderNameFor(source string) string {
+	if name, ok := s.WebhookHeaderName[source]; ok && name != "" {
+		return name
+	}
+	return "X-Signature"
 }
 
 // MT5Config holds MetaTrader 5 connection settings
@@ -38,6 +67,35 @@ type MT5Config struct {
 	TimeoutSeconds int
 	RetryAttempts  int
 	RetryDelayMs   int
+	// BackoffBaseMs and BackoffCapMs parameterize the full-jitter backoff
+	// policy (delay = rand(0, min(cap, base*2^attempt))) used between
+	// retries. Zero falls back to RetryDelayMs/(RetryDelayMs*RetryAttempts).
+	BackoffBaseMs int
+	BackoffCapMs  int
+	// CircuitThreshold is the number of consecutive failures (across
+	// calls, after their own retries are exhausted) that trips the
+	// circuit breaker open. Zero disables the breaker.
+	CircuitThreshold int
+	// CircuitCooldownMs is how long the breaker stays open before letting
+	// a single half-open probe call through.
+	CircuitCooldownMs int
+}
+
+// ExchangeConfig holds credentials for a single execution venue.
+type ExchangeConfig struct {
+	Enabled    bool
+	Endpoint   string
+	APIKey     string
+	APISecret  string
+	Passphrase string // OKX only
+	RecvWindow int     // Bybit only, milliseconds
+}
+
+// ExchangesConfig holds per-venue settings for non-MT5 execution adapters.
+type ExchangesConfig struct {
+	Bybit   ExchangeConfig
+	OKX     ExchangeConfig
+	Binance ExchangeConfig
 }
 
 // RiskConfig holds risk management parameters
@@ -46,6 +104,60 @@ type RiskConfig struct {
 	MaxPositionSize  float64
 	MaxOpenPositions int
 	EnableRiskChecks bool
+	// ReverseSignalAction controls what happens when a new signal opposes
+	// the direction of a symbol's existing open trades: "close" closes
+	// them before opening the new position (the long-standing default),
+	// "hedge" leaves them open and opens an equal-volume opposing
+	// position alongside them, "reduce_only" caps the new position's
+	// volume at the existing net open volume, and "ignore" drops the
+	// signal entirely.
+	ReverseSignalAction string
+	// MaxDailyLossPct trips the RiskGuard kill-switch once today's
+	// realized P/L falls below this percentage of the day's starting
+	// balance.
+	MaxDailyLossPct float64
+	// MaxIntradayDrawdownPct trips the RiskGuard once equity falls this
+	// percentage below its intraday peak.
+	MaxIntradayDrawdownPct float64
+	// MaxConsecutiveLosses trips the RiskGuard after this many closed
+	// trades in a row realize a loss.
+	MaxConsecutiveLosses int
+	// TP1VolumePct is the fraction of the entry trade's actual filled
+	// volume assigned to the TP1 order; the remainder goes to TP2.
+	TP1VolumePct float64
+	// TP1RunnerBreakeven moves the stop-loss on the TP2 runner to the
+	// entry price once TP1 fills, locking in a breakeven trade.
+	TP1RunnerBreakeven bool
+}
+
+// BudgetConfig holds settings for the daily volume/fee budget guard (see
+// internal/signals/volumeguard.go), which rejects new signals once the
+// trading day's accumulated notional volume or a symbol's accumulated
+// broker fees would exceed a configured ceiling.
+type BudgetConfig struct {
+	Enabled bool
+	// DailyMaxVolume caps the total notional volume (price * lots,
+	// summed across every symbol) the trading day may accumulate. Zero
+	// disables the volume check.
+	DailyMaxVolume float64
+	// DailyFeeBudgets caps accumulated broker fees (commission + swap)
+	// per symbol for the trading day, keyed by symbol. A symbol with no
+	// entry is unbounded.
+	DailyFeeBudgets map[string]float64
+}
+
+// InstrumentConfig holds settings for the instrument metadata catalog (see
+// internal/instrument), which normalizes prices/volumes to each symbol's
+// broker-side tick size before they reach the MT5 bridge or the database.
+type InstrumentConfig struct {
+	// SpecsTTLSeconds is how long a symbol's cached TickSize is trusted
+	// before Catalog refreshes it from mt5.Client.GetSymbolSpecs.
+	SpecsTTLSeconds int
+	// StrictPrecision is "strict" to reject a trade outright when its
+	// price/volume can't be normalized onto the symbol's tick size (e.g.
+	// the resulting volume rounds to zero), or "warn" to auto-correct it
+	// and proceed, logging the adjustment.
+	StrictPrecision string
 }
 
 // LoggingConfig holds logging settings
@@ -54,6 +166,20 @@ type LoggingConfig struct {
 	Format string // json or text
 }
 
+// AggregatorConfig holds settings for the multi-source signal aggregator
+// (see internal/signals/aggregator.go), which only creates a trade once
+// enough weighted sources agree on a (symbol, direction) within a sliding
+// window. With default weights of 1.0, VoteThreshold is simply the number
+// of distinct sources required to agree, e.g. 3 to require 3 of 5 sources.
+type AggregatorConfig struct {
+	Enabled       bool
+	WindowSeconds int
+	VoteThreshold float64
+	// SourceWeights holds each source's voting weight, keyed by source
+	// name. Sources not present default to a weight of 1.0.
+	SourceWeights map[string]float64
+}
+
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
 	err := godotenv.Load()
@@ -99,25 +225,75 @@ func Load() (*Config, error) {
 			ConnMaxLifetime: getEnvInt("DB_CONN_MAX_LIFETIME", 15), // Shorter lifetime for better cycling
 		},
 		Server: ServerConfig{
-			Port:          getEnv("SERVER_PORT", "8081"),
-			WebhookSecret: getEnv("WEBHOOK_SECRET", ""),
+			Port:                     getEnv("SERVER_PORT", "8081"),
+			WebhookSecret:            getWebhookSecrets(),
+			WebhookHeaderName:        getWebhookHeaderNames(),
+			IdempotencyWindowSeconds: getEnvInt("WEBHOOK_IDEMPOTENCY_WINDOW_SECONDS", 300),
+			WebhookMaxAgeSeconds:     getEnvInt("WEBHOOK_MAX_AGE_SECONDS", 30),
 		},
 		MT5: MT5Config{
-			Endpoint:       getEnv("MT5_ENDPOINT", "http://localhost:8080"),
-			TimeoutSeconds: getEnvInt("MT5_TIMEOUT_SECONDS", 5),
-			RetryAttempts:  getEnvInt("MT5_RETRY_ATTEMPTS", 3),
-			RetryDelayMs:   getEnvInt("MT5_RETRY_DELAY_MS", 1000),
+			Endpoint:          getEnv("MT5_ENDPOINT", "http://localhost:8080"),
+			TimeoutSeconds:    getEnvInt("MT5_TIMEOUT_SECONDS", 5),
+			RetryAttempts:     getEnvInt("MT5_RETRY_ATTEMPTS", 3),
+			RetryDelayMs:      getEnvInt("MT5_RETRY_DELAY_MS", 1000),
+			BackoffBaseMs:     getEnvInt("MT5_BACKOFF_BASE_MS", 0),
+			BackoffCapMs:      getEnvInt("MT5_BACKOFF_CAP_MS", 0),
+			CircuitThreshold:  getEnvInt("MT5_CIRCUIT_THRESHOLD", 5),
+			CircuitCooldownMs: getEnvInt("MT5_CIRCUIT_COOLDOWN_MS", 30000),
+		},
+		Exchanges: ExchangesConfig{
+			Bybit: ExchangeConfig{
+				Enabled:    getEnvBool("BYBIT_ENABLED", false),
+				Endpoint:   getEnv("BYBIT_ENDPOINT", "https://api.bybit.com"),
+				APIKey:     getEnv("BYBIT_API_KEY", ""),
+				APISecret:  getEnv("BYBIT_API_SECRET", ""),
+				RecvWindow: getEnvInt("BYBIT_RECV_WINDOW_MS", 5000),
+			},
+			OKX: ExchangeConfig{
+				Enabled:    getEnvBool("OKX_ENABLED", false),
+				Endpoint:   getEnv("OKX_ENDPOINT", "https://www.okx.com"),
+				APIKey:     getEnv("OKX_API_KEY", ""),
+				APISecret:  getEnv("OKX_API_SECRET", ""),
+				Passphrase: getEnv("OKX_PASSPHRASE", ""),
+			},
+			Binance: ExchangeConfig{
+				Enabled:   getEnvBool("BINANCE_ENABLED", false),
+				Endpoint:  getEnv("BINANCE_ENDPOINT", "https://fapi.binance.com"),
+				APIKey:    getEnv("BINANCE_API_KEY", ""),
+				APISecret: getEnv("BINANCE_API_SECRET", ""),
+			},
 		},
 		Risk: RiskConfig{
-			MaxDailyLoss:     getEnvFloat("RISK_MAX_DAILY_LOSS", 1000.0),
-			MaxPositionSize:  getEnvFloat("RISK_MAX_POSITION_SIZE", 0.1),
-			MaxOpenPositions: getEnvInt("RISK_MAX_OPEN_POSITIONS", 3),
-			EnableRiskChecks: getEnvBool("RISK_ENABLE_CHECKS", true),
+			MaxDailyLoss:           getEnvFloat("RISK_MAX_DAILY_LOSS", 1000.0),
+			MaxPositionSize:        getEnvFloat("RISK_MAX_POSITION_SIZE", 0.1),
+			MaxOpenPositions:       getEnvInt("RISK_MAX_OPEN_POSITIONS", 3),
+			EnableRiskChecks:       getEnvBool("RISK_ENABLE_CHECKS", true),
+			ReverseSignalAction:    getEnv("RISK_REVERSE_SIGNAL_ACTION", "close"),
+			MaxDailyLossPct:        getEnvFloat("RISK_MAX_DAILY_LOSS_PCT", 5.0),
+			MaxIntradayDrawdownPct: getEnvFloat("RISK_MAX_INTRADAY_DRAWDOWN_PCT", 8.0),
+			MaxConsecutiveLosses:   getEnvInt("RISK_MAX_CONSECUTIVE_LOSSES", 5),
+			TP1VolumePct:           getEnvFloat("RISK_TP1_VOLUME_PCT", 0.5),
+			TP1RunnerBreakeven:     getEnvBool("RISK_TP1_RUNNER_BREAKEVEN", true),
+		},
+		Budget: BudgetConfig{
+			Enabled:         getEnvBool("BUDGET_ENABLED", false),
+			DailyMaxVolume:  getEnvFloat("BUDGET_DAILY_MAX_VOLUME", 0),
+			DailyFeeBudgets: getBudgetFeeBudgets(),
+		},
+		Instrument: InstrumentConfig{
+			SpecsTTLSeconds: getEnvInt("INSTRUMENT_SPECS_TTL_SECONDS", 3600),
+			StrictPrecision: getEnv("INSTRUMENT_STRICT_PRECISION", "warn"),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
+		Aggregator: AggregatorConfig{
+			Enabled:       getEnvBool("AGGREGATOR_ENABLED", false),
+			WindowSeconds: getEnvInt("AGGREGATOR_WINDOW_SECONDS", 60),
+			VoteThreshold: getEnvFloat("AGGREGATOR_VOTE_THRESHOLD", 3.0),
+			SourceWeights: getAggregatorWeights(),
+		},
 		Environment: getEnv("ENVIRONMENT", "development"),
 	}
 
@@ -134,7 +310,7 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DATABASE_URL is required")
 	}
 
-	if c.Server.WebhookSecret == "" {
+	if c.Server.SecretFor("tradingview") == "" {
 		return fmt.Errorf("WEBHOOK_SECRET is required")
 	}
 
@@ -156,6 +332,40 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("RISK_MAX_POSITION_SIZE must be between 0 and 10")
 	}
 
+	if c.Aggregator.Enabled && c.Aggregator.VoteThreshold <= 0 {
+		return fmt.Errorf("AGGREGATOR_VOTE_THRESHOLD must be positive")
+	}
+
+	validReverseSignalActions := []string{"close", "hedge", "reduce_only", "ignore"}
+	if !contains(validReverseSignalActions, c.Risk.ReverseSignalAction) {
+		return fmt.Errorf("invalid RISK_REVERSE_SIGNAL_ACTION: %s, must be one of %v", c.Risk.ReverseSignalAction, validReverseSignalActions)
+	}
+
+	if c.Risk.MaxDailyLossPct <= 0 || c.Risk.MaxDailyLossPct > 100 {
+		return fmt.Errorf("RISK_MAX_DAILY_LOSS_PCT must be between 0 and 100")
+	}
+
+	if c.Risk.MaxIntradayDrawdownPct <= 0 || c.Risk.MaxIntradayDrawdownPct > 100 {
+		return fmt.Errorf("RISK_MAX_INTRADAY_DRAWDOWN_PCT must be between 0 and 100")
+	}
+
+	if c.Risk.MaxConsecutiveLosses <= 0 {
+		return fmt.Errorf("RISK_MAX_CONSECUTIVE_LOSSES must be positive")
+	}
+
+	if c.Risk.TP1VolumePct <= 0 || c.Risk.TP1VolumePct >= 1 {
+		return fmt.Errorf("RISK_TP1_VOLUME_PCT must be between 0 and 1")
+	}
+
+	if c.Budget.Enabled && c.Budget.DailyMaxVolume < 0 {
+		return fmt.Errorf("BUDGET_DAILY_MAX_VOLUME must not be negative")
+	}
+
+	validStrictPrecision := []string{"strict", "warn"}
+	if !contains(validStrictPrecision, c.Instrument.StrictPrecision) {
+		return fmt.Errorf("invalid INSTRUMENT_STRICT_PRECISION: %s, must be one of %v", c.Instrument.StrictPrecision, validStrictPrecision)
+	}
+
 	return nil
 }
 
@@ -205,6 +415,82 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// webhookSources lists the built-in sources that accept a per-source
+// WEBHOOK_SECRET_<SOURCE> / WEBHOOK_HEADER_<SOURCE> override. The legacy
+// WEBHOOK_SECRET variable (no suffix) maps to "tradingview".
+var webhookSources = []string{"tradingview", "discord", "telegram", "github"}
+
+func getWebhookSecrets() map[string]string {
+	secrets := make(map[string]string, len(webhookSources))
+	legacy := getEnv("WEBHOOK_SECRET", "")
+	if legacy != "" {
+		secrets["tradingview"] = legacy
+	}
+	for _, source := range webhookSources {
+		key := "WEBHOOK_SECRET_" + strings.ToUpper(source)
+		if value := getEnv(key, ""); value != "" {
+			secrets[source] = value
+		}
+	}
+	return secrets
+}
+
+func getWebhookHeaderNames() map[string]string {
+	names := make(map[string]string, len(webhookSources))
+	for _, source := range webhookSources {
+		key := "WEBHOOK_HEADER_" + strings.ToUpper(source)
+		if value := getEnv(key, ""); value != "" {
+			names[source] = value
+		}
+	}
+	return names
+}
+
+// aggregatorSources lists the signal sources the aggregator knows how to
+// weight; add to this list alongside webhookSources when a new source is
+// wired into ProcessWebhook/processSignal.
+var aggregatorSources = []string{"tradingview", "discord", "telegram", "mt5_indicator"}
+
+func getAggregatorWeights() map[string]float64 {
+	weights := make(map[string]float64, len(aggregatorSources))
+	for _, source := range aggregatorSources {
+		key := "AGGREGATOR_WEIGHT_" + strings.ToUpper(source)
+		weights[source] = getEnvFloat(key, 1.0)
+	}
+	return weights
+}
+
+// getBudgetFeeBudgets parses BUDGET_DAILY_FEE_BUDGETS, a comma-separated
+// list of SYMBOL=AMOUNT pairs (e.g. "EURUSD=50,XAUUSD=100"). Unlike
+// webhook sources or aggregator sources, symbols aren't a fixed list the
+// repo already knows about, so there's no per-symbol env var to loop over.
+func getBudgetFeeBudgets() map[string]float64 {
+	budgets := make(map[string]float64)
+	raw := getEnv("BUDGET_DAILY_FEE_BUDGETS", "")
+	if raw == "" {
+		return budgets
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		symbol := strings.ToUpper(strings.TrimSpace(kv[0]))
+		amount, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			continue
+		}
+		budgets[symbol] = amount
+	}
+
+	return budgets
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if strings.EqualFold(s, item) {
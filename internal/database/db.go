@@ -3,14 +3,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"trading-system/internal/config"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // DB wraps the database connection and provides repository methods
@@ -46,8 +48,16 @@ func (db *DB) Close() error {
 	return db.conn.Close()
 }
 
-// CreateSignal inserts a new signal into the database
-func (db *DB) CreateSignal(ctx context.Context, req *CreateSignalRequest) (*Signal, error) {
+// querier is satisfied by both *sql.DB and *sql.Tx, letting insertSignal
+// run either standalone (CreateSignal) or as part of a larger transaction
+// (CreateSignalIdempotent).
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// insertSignal runs the signals insert itself, shared by CreateSignal and
+// CreateSignalIdempotent.
+func insertSignal(ctx context.Context, q querier, req *CreateSignalRequest) (*Signal, error) {
 	query := `
 		INSERT INTO signals (source, symbol, signal_type, price, stop_loss, take_profit, tp1, tp2, sl1, sl2, payload)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
@@ -56,7 +66,7 @@ func (db *DB) CreateSignal(ctx context.Context, req *CreateSignalRequest) (*Sign
 	`
 
 	signal := &Signal{}
-	err := db.conn.QueryRowContext(
+	err := q.QueryRowContext(
 		ctx, query,
 		req.Source, req.Symbol, req.SignalType, req.Price, req.StopLoss, req.TakeProfit, req.TP1, req.TP2, req.SL1, req.SL2, req.Payload,
 	).Scan(
@@ -72,6 +82,11 @@ func (db *DB) CreateSignal(ctx context.Context, req *CreateSignalRequest) (*Sign
 	return signal, nil
 }
 
+// CreateSignal inserts a new signal into the database
+func (db *DB) CreateSignal(ctx context.Context, req *CreateSignalRequest) (*Signal, error) {
+	return insertSignal(ctx, db.conn, req)
+}
+
 // GetUnprocessedSignals retrieves all unprocessed signals
 func (db *DB) GetUnprocessedSignals(ctx context.Context) ([]*Signal, error) {
 	query := `
@@ -130,12 +145,39 @@ func (db *DB) MarkSignalProcessed(ctx context.Context, signalID int) error {
 	return nil
 }
 
+// UpdateSignalPayload overwrites a signal's stored payload, used to embed
+// derived provenance (e.g. aggregator consensus, correlation IDs) that
+// wasn't known at ingest time.
+func (db *DB) UpdateSignalPayload(ctx context.Context, signalID int, payload json.RawMessage) error {
+	query := `
+		UPDATE signals
+		SET payload = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	result, err := db.conn.ExecContext(ctx, query, payload, signalID)
+	if err != nil {
+		return fmt.Errorf("failed to update signal payload: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("signal with ID %d not found", signalID)
+	}
+
+	return nil
+}
+
 // CreateTrade inserts a new trade into the database
 func (db *DB) CreateTrade(ctx context.Context, req *CreateTradeRequest) (*Trade, error) {
 	query := `
-		INSERT INTO trades (signal_id, parent_signal_id, parent_trade_id, trade_type, symbol, order_type, direction, volume, entry_price, stop_loss, take_profit, tp1, tp2, sl1, sl2)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-		RETURNING id, uuid, signal_id, parent_signal_id, parent_trade_id, trade_type, symbol, order_type, direction, volume, entry_price,
+		INSERT INTO trades (signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price, stop_loss, take_profit, tp1, tp2, sl1, sl2)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id, uuid, signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price,
 		          current_price, stop_loss, take_profit, tp1, tp2, sl1, sl2, status, mt5_ticket, mt5_response,
 		          profit_loss, commission, swap, created_at, updated_at, closed_at
 	`
@@ -143,10 +185,10 @@ func (db *DB) CreateTrade(ctx context.Context, req *CreateTradeRequest) (*Trade,
 	trade := &Trade{}
 	err := db.conn.QueryRowContext(
 		ctx, query,
-		req.SignalID, req.ParentSignalID, req.ParentTradeID, req.TradeType, req.Symbol, req.OrderType, req.Direction, req.Volume,
+		req.SignalID, req.ParentSignalID, req.ParentTradeID, req.HedgeOf, req.TradeType, req.Symbol, req.OrderType, req.Direction, req.Volume,
 		req.EntryPrice, req.StopLoss, req.TakeProfit, req.TP1, req.TP2, req.SL1, req.SL2,
 	).Scan(
-		&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.TradeType, &trade.Symbol, &trade.OrderType,
+		&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.HedgeOf, &trade.TradeType, &trade.Symbol, &trade.OrderType,
 		&trade.Direction, &trade.Volume, &trade.EntryPrice, &trade.CurrentPrice,
 		&trade.StopLoss, &trade.TakeProfit, &trade.TP1, &trade.TP2, &trade.SL1, &trade.SL2, &trade.Status, &trade.MT5Ticket,
 		&trade.MT5Response, &trade.ProfitLoss, &trade.Commission, &trade.Swap,
@@ -160,6 +202,70 @@ func (db *DB) CreateTrade(ctx context.Context, req *CreateTradeRequest) (*Trade,
 	return trade, nil
 }
 
+// CreateTradesBatch inserts every req in reqs with a single multi-row
+// INSERT ... VALUES ... RETURNING, for SendTradeBatch's bulk leg
+// submission. pq.CopyIn's COPY protocol would need a second round trip to
+// read back each row's generated id/defaults (COPY has no RETURNING), so
+// a multi-row VALUES list is the only way to get CreateTrade's per-leg
+// Trade back in one statement. Returned trades are aligned with reqs by
+// index, same contract as SendTradeBatch.
+func (db *DB) CreateTradesBatch(ctx context.Context, reqs []*CreateTradeRequest) ([]*Trade, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	const colsPerRow = 16
+	placeholders := make([]string, len(reqs))
+	args := make([]interface{}, 0, len(reqs)*colsPerRow)
+
+	for i, req := range reqs {
+		base := i * colsPerRow
+		placeholders[i] = fmt.Sprintf(
+			"($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+7, base+8,
+			base+9, base+10, base+11, base+12, base+13, base+14, base+15, base+16,
+		)
+		args = append(args,
+			req.SignalID, req.ParentSignalID, req.ParentTradeID, req.HedgeOf, req.TradeType, req.Symbol, req.OrderType, req.Direction, req.Volume,
+			req.EntryPrice, req.StopLoss, req.TakeProfit, req.TP1, req.TP2, req.SL1, req.SL2,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO trades (signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price, stop_loss, take_profit, tp1, tp2, sl1, sl2)
+		VALUES %s
+		RETURNING id, uuid, signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price,
+		          current_price, stop_loss, take_profit, tp1, tp2, sl1, sl2, status, mt5_ticket, mt5_response,
+		          profit_loss, commission, swap, created_at, updated_at, closed_at
+	`, strings.Join(placeholders, ", "))
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch create trades: %w", err)
+	}
+	defer rows.Close()
+
+	trades := make([]*Trade, 0, len(reqs))
+	for rows.Next() {
+		trade := &Trade{}
+		if err := rows.Scan(
+			&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.HedgeOf, &trade.TradeType, &trade.Symbol, &trade.OrderType,
+			&trade.Direction, &trade.Volume, &trade.EntryPrice, &trade.CurrentPrice,
+			&trade.StopLoss, &trade.TakeProfit, &trade.TP1, &trade.TP2, &trade.SL1, &trade.SL2, &trade.Status, &trade.MT5Ticket,
+			&trade.MT5Response, &trade.ProfitLoss, &trade.Commission, &trade.Swap,
+			&trade.CreatedAt, &trade.UpdatedAt, &trade.ClosedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan batch-created trade: %w", err)
+		}
+		trades = append(trades, trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch-created trades: %w", err)
+	}
+
+	return trades, nil
+}
+
 // UpdateTradeStatus updates the status and details of a trade
 func (db *DB) UpdateTradeStatus(ctx context.Context, tradeID int, req *UpdateTradeStatusRequest) error {
 
@@ -195,6 +301,18 @@ func (db *DB) UpdateTradeStatus(ctx context.Context, tradeID int, req *UpdateTra
 		argIndex++
 	}
 
+	if req.Volume != nil {
+		setParts = append(setParts, fmt.Sprintf("volume = $%d", argIndex))
+		args = append(args, *req.Volume)
+		argIndex++
+	}
+
+	if req.StopLoss != nil {
+		setParts = append(setParts, fmt.Sprintf("stop_loss = $%d", argIndex))
+		args = append(args, *req.StopLoss)
+		argIndex++
+	}
+
 	if req.CurrentPrice != nil {
 		setParts = append(setParts, fmt.Sprintf("current_price = $%d", argIndex))
 		args = append(args, *req.CurrentPrice)
@@ -256,10 +374,10 @@ func (db *DB) UpdateTradeStatus(ctx context.Context, tradeID int, req *UpdateTra
 // GetOpenTrades retrieves all open trades
 func (db *DB) GetOpenTrades(ctx context.Context) ([]*Trade, error) {
 	query := `
-		SELECT id, uuid, signal_id, parent_signal_id, parent_trade_id, trade_type, symbol, order_type, direction, volume, entry_price,
+		SELECT id, uuid, signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price,
 		       current_price, stop_loss, take_profit, tp1, tp2, sl1, sl2, status, mt5_ticket, mt5_response,
 		       profit_loss, commission, swap, created_at, updated_at, closed_at
-		FROM trades 
+		FROM trades
 		WHERE status IN ('pending', 'filled', 'partial')
 		ORDER BY created_at ASC
 	`
@@ -274,7 +392,7 @@ func (db *DB) GetOpenTrades(ctx context.Context) ([]*Trade, error) {
 	for rows.Next() {
 		trade := &Trade{}
 		err := rows.Scan(
-			&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.TradeType, &trade.Symbol, &trade.OrderType,
+			&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.HedgeOf, &trade.TradeType, &trade.Symbol, &trade.OrderType,
 			&trade.Direction, &trade.Volume, &trade.EntryPrice, &trade.CurrentPrice,
 			&trade.StopLoss, &trade.TakeProfit, &trade.TP1, &trade.TP2, &trade.SL1, &trade.SL2, &trade.Status, &trade.MT5Ticket,
 			&trade.MT5Response, &trade.ProfitLoss, &trade.Commission, &trade.Swap,
@@ -289,13 +407,39 @@ func (db *DB) GetOpenTrades(ctx context.Context) ([]*Trade, error) {
 	return trades, nil
 }
 
+// GetTradeByID retrieves a single trade by its primary key, e.g. so
+// executeTPTrade can look up the entry trade a TP order is scaling out of.
+func (db *DB) GetTradeByID(ctx context.Context, tradeID int) (*Trade, error) {
+	query := `
+		SELECT id, uuid, signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price,
+		       current_price, stop_loss, take_profit, tp1, tp2, sl1, sl2, status, mt5_ticket, mt5_response,
+		       profit_loss, commission, swap, created_at, updated_at, closed_at
+		FROM trades
+		WHERE id = $1
+	`
+
+	trade := &Trade{}
+	err := db.conn.QueryRowContext(ctx, query, tradeID).Scan(
+		&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.HedgeOf, &trade.TradeType, &trade.Symbol, &trade.OrderType,
+		&trade.Direction, &trade.Volume, &trade.EntryPrice, &trade.CurrentPrice,
+		&trade.StopLoss, &trade.TakeProfit, &trade.TP1, &trade.TP2, &trade.SL1, &trade.SL2, &trade.Status, &trade.MT5Ticket,
+		&trade.MT5Response, &trade.ProfitLoss, &trade.Commission, &trade.Swap,
+		&trade.CreatedAt, &trade.UpdatedAt, &trade.ClosedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade %d: %w", tradeID, err)
+	}
+
+	return trade, nil
+}
+
 // GetTradesByParent retrieves all child trades for a given parent trade ID
 func (db *DB) GetTradesByParent(ctx context.Context, parentTradeID int) ([]*Trade, error) {
 	query := `
-		SELECT id, uuid, signal_id, parent_signal_id, parent_trade_id, trade_type, symbol, order_type, direction, volume, entry_price,
+		SELECT id, uuid, signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price,
 		       current_price, stop_loss, take_profit, tp1, tp2, sl1, sl2, status, mt5_ticket, mt5_response,
 		       profit_loss, commission, swap, created_at, updated_at, closed_at
-		FROM trades 
+		FROM trades
 		WHERE parent_trade_id = $1
 		ORDER BY created_at ASC
 	`
@@ -310,7 +454,7 @@ func (db *DB) GetTradesByParent(ctx context.Context, parentTradeID int) ([]*Trad
 	for rows.Next() {
 		trade := &Trade{}
 		err := rows.Scan(
-			&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.TradeType, &trade.Symbol, &trade.OrderType,
+			&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.HedgeOf, &trade.TradeType, &trade.Symbol, &trade.OrderType,
 			&trade.Direction, &trade.Volume, &trade.EntryPrice, &trade.CurrentPrice,
 			&trade.StopLoss, &trade.TakeProfit, &trade.TP1, &trade.TP2, &trade.SL1, &trade.SL2, &trade.Status, &trade.MT5Ticket,
 			&trade.MT5Response, &trade.ProfitLoss, &trade.Commission, &trade.Swap,
@@ -325,6 +469,288 @@ func (db *DB) GetTradesByParent(ctx context.Context, parentTradeID int) ([]*Trad
 	return trades, nil
 }
 
+// GetTradesByHedgeOf retrieves all trades opened to hedge a given trade ID
+// (see Trade.HedgeOf), e.g. the secondary-venue leg opened alongside an
+// entry trade for a cross-exchange hedged signal.
+func (db *DB) GetTradesByHedgeOf(ctx context.Context, hedgeOfTradeID int) ([]*Trade, error) {
+	query := `
+		SELECT id, uuid, signal_id, parent_signal_id, parent_trade_id, hedge_of, trade_type, symbol, order_type, direction, volume, entry_price,
+		       current_price, stop_loss, take_profit, tp1, tp2, sl1, sl2, status, mt5_ticket, mt5_response,
+		       profit_loss, commission, swap, created_at, updated_at, closed_at
+		FROM trades
+		WHERE hedge_of = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := db.conn.QueryContext(ctx, query, hedgeOfTradeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query hedge trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*Trade
+	for rows.Next() {
+		trade := &Trade{}
+		err := rows.Scan(
+			&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.HedgeOf, &trade.TradeType, &trade.Symbol, &trade.OrderType,
+			&trade.Direction, &trade.Volume, &trade.EntryPrice, &trade.CurrentPrice,
+			&trade.StopLoss, &trade.TakeProfit, &trade.TP1, &trade.TP2, &trade.SL1, &trade.SL2, &trade.Status, &trade.MT5Ticket,
+			&trade.MT5Response, &trade.ProfitLoss, &trade.Commission, &trade.Swap,
+			&trade.CreatedAt, &trade.UpdatedAt, &trade.ClosedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan hedge trade: %w", err)
+		}
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+// Cursor is an opaque keyset pagination token returned by QueryClosedTrades.
+// Callers must not parse its contents directly - decode it with ParseCursor
+// and feed the result back into the next call's TradeFilter.Until/LastTradeID.
+type Cursor string
+
+// EncodeCursor packs a (closed_at, id) keyset position into an opaque
+// Cursor token.
+func EncodeCursor(closedAt time.Time, id int) Cursor {
+	raw := fmt.Sprintf("%s|%d", closedAt.UTC().Format(time.RFC3339Nano), id)
+	return Cursor(base64.URLEncoding.EncodeToString([]byte(raw)))
+}
+
+// ParseCursor unpacks a Cursor token back into the (closed_at, id) keyset
+// position it encodes.
+func ParseCursor(cursor Cursor) (time.Time, int, error) {
+	raw, err := base64.URLEncoding.DecodeString(string(cursor))
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor: malformed payload")
+	}
+
+	closedAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor: malformed timestamp: %w", err)
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor: malformed id: %w", err)
+	}
+
+	return closedAt, id, nil
+}
+
+// TradeFilter narrows a QueryClosedTrades query. Zero values are
+// unconstrained except Status, which defaults to {"closed"} when empty.
+// To fetch the next page, decode the previous call's Cursor with
+// ParseCursor and copy the result into Until/LastTradeID.
+type TradeFilter struct {
+	Symbol      string
+	Source      string
+	Direction   string
+	Status      []string
+	Since       time.Time
+	Until       time.Time
+	LastTradeID int
+	Limit       int
+}
+
+// defaultTradeQueryLimit caps a QueryClosedTrades page when filter.Limit is unset.
+const defaultTradeQueryLimit = 100
+
+// QueryClosedTrades pages through closed trades most-recent-first using
+// keyset pagination on (closed_at, id) rather than OFFSET, so deep pages
+// stay cheap under millions of rows (see Cursor). Mirrors GetSystemLogs's
+// dynamic WHERE-building for the optional filters.
+func (db *DB) QueryClosedTrades(ctx context.Context, filter TradeFilter) ([]*Trade, Cursor, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultTradeQueryLimit
+	}
+
+	statuses := filter.Status
+	if len(statuses) == 0 {
+		statuses = []string{"closed"}
+	}
+
+	where := []string{"t.closed_at IS NOT NULL"}
+	var args []interface{}
+	argIndex := 1
+
+	statusPlaceholders := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusPlaceholders[i] = fmt.Sprintf("$%d", argIndex)
+		args = append(args, status)
+		argIndex++
+	}
+	where = append(where, fmt.Sprintf("t.status IN (%s)", strings.Join(statusPlaceholders, ", ")))
+
+	if filter.Symbol != "" {
+		where = append(where, fmt.Sprintf("t.symbol = $%d", argIndex))
+		args = append(args, filter.Symbol)
+		argIndex++
+	}
+
+	if filter.Direction != "" {
+		where = append(where, fmt.Sprintf("t.direction = $%d", argIndex))
+		args = append(args, filter.Direction)
+		argIndex++
+	}
+
+	if !filter.Since.IsZero() {
+		where = append(where, fmt.Sprintf("t.closed_at >= $%d", argIndex))
+		args = append(args, filter.Since)
+		argIndex++
+	}
+
+	if !filter.Until.IsZero() {
+		where = append(where, fmt.Sprintf("(t.closed_at, t.id) < ($%d, $%d)", argIndex, argIndex+1))
+		args = append(args, filter.Until, filter.LastTradeID)
+		argIndex += 2
+	}
+
+	query := `
+		SELECT t.id, t.uuid, t.signal_id, t.parent_signal_id, t.parent_trade_id, t.hedge_of, t.trade_type, t.symbol, t.order_type, t.direction, t.volume, t.entry_price,
+		       t.current_price, t.stop_loss, t.take_profit, t.tp1, t.tp2, t.sl1, t.sl2, t.status, t.mt5_ticket, t.mt5_response,
+		       t.profit_loss, t.commission, t.swap, t.created_at, t.updated_at, t.closed_at
+		FROM trades t
+	`
+	if filter.Source != "" {
+		query += " JOIN signals s ON t.signal_id = s.id"
+		where = append(where, fmt.Sprintf("s.source = $%d", argIndex))
+		args = append(args, filter.Source)
+		argIndex++
+	}
+	query += " WHERE " + strings.Join(where, " AND ")
+	query += fmt.Sprintf(" ORDER BY t.closed_at DESC, t.id DESC LIMIT $%d", argIndex)
+	args = append(args, limit+1)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query closed trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*Trade
+	for rows.Next() {
+		trade := &Trade{}
+		err := rows.Scan(
+			&trade.ID, &trade.UUID, &trade.SignalID, &trade.ParentSignalID, &trade.ParentTradeID, &trade.HedgeOf, &trade.TradeType, &trade.Symbol, &trade.OrderType,
+			&trade.Direction, &trade.Volume, &trade.EntryPrice, &trade.CurrentPrice,
+			&trade.StopLoss, &trade.TakeProfit, &trade.TP1, &trade.TP2, &trade.SL1, &trade.SL2, &trade.Status, &trade.MT5Ticket,
+			&trade.MT5Response, &trade.ProfitLoss, &trade.Commission, &trade.Swap,
+			&trade.CreatedAt, &trade.UpdatedAt, &trade.ClosedAt,
+		)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan closed trade: %w", err)
+		}
+		trades = append(trades, trade)
+	}
+
+	var nextCursor Cursor
+	if len(trades) > limit {
+		trades = trades[:limit]
+		last := trades[len(trades)-1]
+		if last.ClosedAt != nil {
+			nextCursor = EncodeCursor(*last.ClosedAt, last.ID)
+		}
+	}
+
+	return trades, nextCursor, nil
+}
+
+// FindIdempotentSignal looks up a previously recorded signal for a content
+// hash, returning nil if none exists (or the hash is unknown). Callers are
+// expected to have already scoped their own freshness window using
+// PurgeExpiredIdempotency, since expired-but-not-yet-purged rows are still
+// considered valid here.
+func (db *DB) FindIdempotentSignal(ctx context.Context, contentHash string) (*int, error) {
+	var signalID int
+	err := db.conn.QueryRowContext(ctx,
+		`SELECT signal_id FROM signals_idempotency WHERE content_hash = $1`,
+		contentHash,
+	).Scan(&signalID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up idempotency record: %w", err)
+	}
+
+	return &signalID, nil
+}
+
+// pqUniqueViolation is the "unique_violation" SQLSTATE code, used to detect
+// a lost idempotency race in CreateSignalIdempotent without string-matching
+// driver error text.
+const pqUniqueViolation = "23505"
+
+// CreateSignalIdempotent creates signal and its signals_idempotency record
+// atomically in one transaction, closing the check-then-act race a plain
+// FindIdempotentSignal-then-CreateSignal sequence has: two concurrent
+// identical webhooks can otherwise both see "not found" and each insert
+// their own Signal row. Here, whichever request's transaction commits
+// second gets a unique_violation on content_hash, rolls back its own
+// signal insert, and returns the winning request's signal instead -
+// callers always get back exactly one signal per content hash.
+func (db *DB) CreateSignalIdempotent(ctx context.Context, req *CreateSignalRequest, contentHash string) (*Signal, error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin idempotent signal transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	signal, err := insertSignal(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO signals_idempotency (content_hash, signal_id) VALUES ($1, $2)`,
+		contentHash, signal.ID,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pqUniqueViolation {
+			tx.Rollback()
+			existingID, findErr := db.FindIdempotentSignal(ctx, contentHash)
+			if findErr != nil {
+				return nil, fmt.Errorf("failed to look up winning signal after idempotency conflict: %w", findErr)
+			}
+			if existingID == nil {
+				return nil, fmt.Errorf("idempotency conflict for hash %s but no winning record found", contentHash)
+			}
+			return &Signal{ID: *existingID, Source: req.Source, Symbol: req.Symbol, SignalType: req.SignalType}, nil
+		}
+		return nil, fmt.Errorf("failed to record idempotency: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit idempotent signal creation: %w", err)
+	}
+
+	return signal, nil
+}
+
+// PurgeExpiredIdempotency removes idempotency records older than olderThan,
+// allowing identical alerts to be reprocessed once they age out of the
+// replay-protection window.
+func (db *DB) PurgeExpiredIdempotency(ctx context.Context, olderThan time.Duration) error {
+	_, err := db.conn.ExecContext(ctx,
+		`DELETE FROM signals_idempotency WHERE created_at < $1`,
+		time.Now().Add(-olderThan),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to purge expired idempotency records: %w", err)
+	}
+	return nil
+}
+
 // LogEvent logs a system event
 func (db *DB) LogEvent(ctx context.Context, level, message, component string, context json.RawMessage) error {
 	query := `
@@ -339,3 +765,259 @@ func (db *DB) LogEvent(ctx context.Context, level, message, component string, co
 
 	return nil
 }
+
+// CreateExecutionPosition starts tracking a sliced execution algorithm run
+// (see internal/execution) against its parent entry trade.
+func (db *DB) CreateExecutionPosition(ctx context.Context, req *CreateExecutionPositionRequest) (*ExecutionPosition, error) {
+	query := `
+		INSERT INTO execution_positions (trade_id, algo, target_volume, filled_volume, status)
+		VALUES ($1, $2, $3, 0, 'running')
+		RETURNING id, trade_id, algo, target_volume, filled_volume, status, created_at, updated_at, completed_at
+	`
+
+	pos := &ExecutionPosition{}
+	err := db.conn.QueryRowContext(ctx, query, req.TradeID, req.Algo, req.TargetVolume).Scan(
+		&pos.ID, &pos.TradeID, &pos.Algo, &pos.TargetVolume, &pos.FilledVolume, &pos.Status,
+		&pos.CreatedAt, &pos.UpdatedAt, &pos.CompletedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create execution position: %w", err)
+	}
+
+	return pos, nil
+}
+
+// UpdateExecutionPositionFilled records the cumulative filled volume for a
+// running execution position, and optionally closes it out with a final
+// status ("completed" or "cancelled").
+func (db *DB) UpdateExecutionPositionFilled(ctx context.Context, id int, filledVolume float64, status string) error {
+	query := `
+		UPDATE execution_positions
+		SET filled_volume = $1, status = $2, updated_at = NOW(),
+		    completed_at = CASE WHEN $2 IN ('completed', 'cancelled') THEN NOW() ELSE completed_at END
+		WHERE id = $3
+	`
+
+	_, err := db.conn.ExecContext(ctx, query, filledVolume, status, id)
+	if err != nil {
+		return fmt.Errorf("failed to update execution position: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrCreateRiskGuardState returns today's RiskGuard baseline, creating
+// it from startingBalance if this is the first signal processed on
+// tradingDay (so a restart mid-session resumes from the persisted
+// baseline instead of re-stamping the balance at the moment of restart).
+func (db *DB) GetOrCreateRiskGuardState(ctx context.Context, tradingDay time.Time, startingBalance float64) (*RiskGuardState, error) {
+	state := &RiskGuardState{}
+	err := db.conn.QueryRowContext(ctx, `
+		SELECT id, trading_day, starting_balance, peak_equity, realized_pnl, consecutive_losses, tripped, trip_reason, created_at, updated_at
+		FROM risk_guard_state
+		WHERE trading_day = $1
+	`, tradingDay).Scan(
+		&state.ID, &state.TradingDay, &state.StartingBalance, &state.PeakEquity, &state.RealizedPnL,
+		&state.ConsecutiveLosses, &state.Tripped, &state.TripReason, &state.CreatedAt, &state.UpdatedAt,
+	)
+	if err == nil {
+		return state, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to query risk guard state: %w", err)
+	}
+
+	err = db.conn.QueryRowContext(ctx, `
+		INSERT INTO risk_guard_state (trading_day, starting_balance, peak_equity, realized_pnl)
+		VALUES ($1, $2, $2, 0)
+		ON CONFLICT (trading_day) DO UPDATE SET trading_day = EXCLUDED.trading_day
+		RETURNING id, trading_day, starting_balance, peak_equity, realized_pnl, consecutive_losses, tripped, trip_reason, created_at, updated_at
+	`, tradingDay, startingBalance).Scan(
+		&state.ID, &state.TradingDay, &state.StartingBalance, &state.PeakEquity, &state.RealizedPnL,
+		&state.ConsecutiveLosses, &state.Tripped, &state.TripReason, &state.CreatedAt, &state.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create risk guard state: %w", err)
+	}
+
+	return state, nil
+}
+
+// UpdateRiskGuardState persists an updated RiskGuard snapshot.
+func (db *DB) UpdateRiskGuardState(ctx context.Context, id int, req *UpdateRiskGuardStateRequest) error {
+	_, err := db.conn.ExecContext(ctx, `
+		UPDATE risk_guard_state
+		SET peak_equity = $1, realized_pnl = $2, consecutive_losses = $3, tripped = $4, trip_reason = $5, updated_at = NOW()
+		WHERE id = $6
+	`, req.PeakEquity, req.RealizedPnL, req.ConsecutiveLosses, req.Tripped, req.TripReason, id)
+	if err != nil {
+		return fmt.Errorf("failed to update risk guard state: %w", err)
+	}
+
+	return nil
+}
+
+// ResetRiskGuardState clears a tripped RiskGuard's trip flag and losing
+// streak, for the manual reset endpoint. The realized P/L and peak equity
+// baselines are left untouched - a reset un-blocks trading, it doesn't
+// erase the day's history.
+func (db *DB) ResetRiskGuardState(ctx context.Context, id int) error {
+	_, err := db.conn.ExecContext(ctx, `
+		UPDATE risk_guard_state
+		SET tripped = false, trip_reason = '', consecutive_losses = 0, updated_at = NOW()
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to reset risk guard state: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrCreateBudgetState returns today's VolumeGuard baseline, creating an
+// empty one (zero volume, empty fee map) if this is the first signal
+// processed on tradingDay.
+func (db *DB) GetOrCreateBudgetState(ctx context.Context, tradingDay time.Time) (*BudgetState, error) {
+	state := &BudgetState{}
+	err := db.conn.QueryRowContext(ctx, `
+		SELECT id, trading_day, accumulated_volume, accumulated_fees, created_at, updated_at
+		FROM budget_state
+		WHERE trading_day = $1
+	`, tradingDay).Scan(
+		&state.ID, &state.TradingDay, &state.AccumulatedVolume, &state.AccumulatedFees, &state.CreatedAt, &state.UpdatedAt,
+	)
+	if err == nil {
+		return state, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to query budget state: %w", err)
+	}
+
+	err = db.conn.QueryRowContext(ctx, `
+		INSERT INTO budget_state (trading_day, accumulated_volume, accumulated_fees)
+		VALUES ($1, 0, '{}')
+		ON CONFLICT (trading_day) DO UPDATE SET trading_day = EXCLUDED.trading_day
+		RETURNING id, trading_day, accumulated_volume, accumulated_fees, created_at, updated_at
+	`, tradingDay).Scan(
+		&state.ID, &state.TradingDay, &state.AccumulatedVolume, &state.AccumulatedFees, &state.CreatedAt, &state.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create budget state: %w", err)
+	}
+
+	return state, nil
+}
+
+// UpdateBudgetState persists an updated VolumeGuard snapshot.
+func (db *DB) UpdateBudgetState(ctx context.Context, id int, req *UpdateBudgetStateRequest) error {
+	_, err := db.conn.ExecContext(ctx, `
+		UPDATE budget_state
+		SET accumulated_volume = $1, accumulated_fees = $2, updated_at = NOW()
+		WHERE id = $3
+	`, req.AccumulatedVolume, req.AccumulatedFees, id)
+	if err != nil {
+		return fmt.Errorf("failed to update budget state: %w", err)
+	}
+
+	return nil
+}
+
+// GetInstrumentSpec looks up symbol's cached precision metadata (see
+// internal/instrument.Catalog). Returns sql.ErrNoRows if symbol has never
+// been refreshed from MT5.
+func (db *DB) GetInstrumentSpec(ctx context.Context, symbol string) (*InstrumentSpec, error) {
+	spec := &InstrumentSpec{}
+	err := db.conn.QueryRowContext(ctx, `
+		SELECT id, symbol, price_tick_size, volume_tick_size, min_volume, max_volume, contract_size, created_at, updated_at
+		FROM instrument_specs
+		WHERE symbol = $1
+	`, symbol).Scan(
+		&spec.ID, &spec.Symbol, &spec.PriceTickSize, &spec.VolumeTickSize, &spec.MinVolume, &spec.MaxVolume, &spec.ContractSize,
+		&spec.CreatedAt, &spec.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// UpsertInstrumentSpec persists a symbol's refreshed precision metadata,
+// overwriting any previously cached row.
+func (db *DB) UpsertInstrumentSpec(ctx context.Context, req *UpsertInstrumentSpecRequest) error {
+	_, err := db.conn.ExecContext(ctx, `
+		INSERT INTO instrument_specs (symbol, price_tick_size, volume_tick_size, min_volume, max_volume, contract_size)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (symbol) DO UPDATE SET
+			price_tick_size  = EXCLUDED.price_tick_size,
+			volume_tick_size = EXCLUDED.volume_tick_size,
+			min_volume       = EXCLUDED.min_volume,
+			max_volume       = EXCLUDED.max_volume,
+			contract_size    = EXCLUDED.contract_size,
+			updated_at       = NOW()
+	`, req.Symbol, req.PriceTickSize, req.VolumeTickSize, req.MinVolume, req.MaxVolume, req.ContractSize)
+	if err != nil {
+		return fmt.Errorf("failed to upsert instrument spec for %s: %w", req.Symbol, err)
+	}
+
+	return nil
+}
+
+// SystemLogFilter narrows a GetSystemLogs query. Zero values are
+// unconstrained: an empty CorrelationID/Level matches any row, and a zero
+// Since matches any time.
+type SystemLogFilter struct {
+	CorrelationID string
+	Level         string
+	Since         time.Time
+}
+
+// GetSystemLogs retrieves system log rows matching filter, most recent
+// first, so operators can trace a single correlation ID (see
+// internal/logging) across components without grepping files.
+func (db *DB) GetSystemLogs(ctx context.Context, filter SystemLogFilter) ([]*SystemLog, error) {
+	var where []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.CorrelationID != "" {
+		where = append(where, fmt.Sprintf("context->>'correlation_id' = $%d", argIndex))
+		args = append(args, filter.CorrelationID)
+		argIndex++
+	}
+
+	if filter.Level != "" {
+		where = append(where, fmt.Sprintf("level = $%d", argIndex))
+		args = append(args, filter.Level)
+		argIndex++
+	}
+
+	if !filter.Since.IsZero() {
+		where = append(where, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, filter.Since)
+		argIndex++
+	}
+
+	query := "SELECT id, level, message, component, context, created_at FROM system_logs"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC LIMIT 500"
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query system logs: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*SystemLog
+	for rows.Next() {
+		entry := &SystemLog{}
+		if err := rows.Scan(&entry.ID, &entry.Level, &entry.Message, &entry.Component, &entry.Context, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan system log: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // Signal represents a trading signal from TradingView or other sources
@@ -35,6 +36,12 @@ type Trade struct {
 	SignalID       *int             `json:"signal_id,omitempty" db:"signal_id"`
 	ParentSignalID *int             `json:"parent_signal_id,omitempty" db:"parent_signal_id"`
 	ParentTradeID  *int             `json:"parent_trade_id,omitempty" db:"parent_trade_id"`
+	// HedgeOf references the trade this one was opened to hedge: set when
+	// the processor's ReverseSignalAction is "hedge" (see
+	// Processor.applyReverseSignalAction) and on the second-venue leg a
+	// signal's HedgeConfig opens (see Processor.openHedgeLeg). Nil for
+	// non-hedge trades.
+	HedgeOf        *int             `json:"hedge_of,omitempty" db:"hedge_of"`
 	TradeType      string           `json:"trade_type" db:"trade_type"`
 	Symbol         string           `json:"symbol" db:"symbol"`
 	OrderType      string           `json:"order_type" db:"order_type"`
@@ -83,32 +90,112 @@ type RiskEvent struct {
 
 // TradingViewWebhook represents the incoming webhook payload from TradingView
 type TradingViewWebhook struct {
-	Ticker     string          `json:"ticker"`
-	Action     string          `json:"action"`
-	Price      *float64        `json:"price,omitempty"`
-	Entry      *float64        `json:"entry,omitempty"` // Alternative to price
-	StopLoss   *float64        `json:"stop_loss,omitempty"`
-	TakeProfit *float64        `json:"take_profit,omitempty"`
-	TP1        *float64        `json:"tp1,omitempty"`
-	TP2        *float64        `json:"tp2,omitempty"`
+	Ticker string `json:"ticker"`
+	Action string `json:"action"`
+	// Price, Entry, StopLoss, TakeProfit, TP1, and TP2 are decoded as
+	// json.Number (the literal text of the JSON number) rather than
+	// float64, so a value like 1.123456789 survives parsing into
+	// decimal.NewFromString downstream (see validatePrice in processor.go)
+	// instead of first being rounded by a lossy float64 unmarshal. An
+	// absent or null field decodes to "".
+	Price      json.Number     `json:"price,omitempty"`
+	Entry      json.Number     `json:"entry,omitempty"` // Alternative to price
+	StopLoss   json.Number     `json:"stop_loss,omitempty"`
+	TakeProfit json.Number     `json:"take_profit,omitempty"`
+	TP1        json.Number     `json:"tp1,omitempty"`
+	TP2        json.Number     `json:"tp2,omitempty"`
 	Volume     *float64        `json:"volume,omitempty"`
 	Message    string          `json:"message,omitempty"`
 	Timestamp  json.RawMessage `json:"timestamp,omitempty"` // Flexible: accepts both number and string
+	Exchange   string          `json:"exchange,omitempty"`  // Explicit venue override, e.g. "bybit"
+
+	// CorrelationID carries the request correlation ID stamped at webhook
+	// ingress (see internal/logging) through to async processing.
+	CorrelationID string `json:"_correlation_id,omitempty"`
+	// Execution opts this signal into a sliced execution algorithm (see
+	// internal/execution) instead of a single market order.
+	Execution *ExecutionRequest `json:"execution,omitempty"`
+	// Hedge opts this signal into opening a linked opposite-side position
+	// on a second execution venue alongside the primary entry trade (see
+	// Processor.openHedgeLeg).
+	Hedge *HedgeConfig `json:"hedge,omitempty"`
+	// Strategy selects an alternate execution strategy for this signal
+	// instead of a single entry trade, e.g. "dca". Empty uses the default
+	// single-entry flow.
+	Strategy string `json:"strategy,omitempty"`
+	// DCA configures a dollar-cost-averaging ladder of entry legs when
+	// Strategy is "dca" (see internal/strategy/dca).
+	DCA *DCAConfig `json:"dca,omitempty"`
+}
+
+// DCAConfig configures a dollar-cost-averaging ladder of entry legs (see
+// internal/strategy/dca.Plan): a single signal is split into
+// MaxOrderCount limit legs at progressively worse prices, sized to stay
+// within QuoteInvestment, sharing one take profit computed off the
+// ladder's volume-weighted average entry.
+type DCAConfig struct {
+	MaxOrderCount   int     `json:"max_order_count"`
+	PriceDeviation  float64 `json:"price_deviation"`
+	VolumeScale     float64 `json:"volume_scale"`
+	ScaleMode       string  `json:"scale_mode,omitempty"` // "geometric" (default) or "linear"
+	TakeProfitRatio float64 `json:"take_profit_ratio"`
+	QuoteInvestment float64 `json:"quote_investment"`
+	MinVolume       float64 `json:"min_volume,omitempty"`
+	VolumeStep      float64 `json:"volume_step,omitempty"`
+}
+
+// HedgeConfig opts a signal into a cross-venue hedged execution mode: the
+// primary entry trade is placed as usual (MT5 or a router-resolved
+// exchange), and an opposite-side leg of hedge_ratio * the primary volume
+// is placed on a second venue, linked back via Trade.HedgeOf. This targets
+// funding-rate/basis strategies driven from a single TradingView alert
+// rather than only directional trades.
+type HedgeConfig struct {
+	// Session names the router-registered exchange adapter for the hedge
+	// leg (see router.Router.Get), e.g. "bybit".
+	Session string `json:"hedge_session"`
+	// Symbol is the hedge venue's own symbol for the instrument, e.g.
+	// "BTCUSDT". Should carry the adapter's registered prefix (see
+	// router.Router.Register) so the hedge leg can be resolved again by
+	// symbol alone when it's time to close it out.
+	Symbol string `json:"hedge_symbol"`
+	// Ratio sizes the hedge leg's volume as a fraction of the primary
+	// trade's volume, e.g. 1.0 for a full 1:1 hedge.
+	Ratio float64 `json:"hedge_ratio"`
+	// Leverage requests venue-side leverage for the hedge leg. Zero leaves
+	// the venue's existing/default leverage untouched.
+	Leverage float64 `json:"hedge_leverage,omitempty"`
+}
+
+// ExecutionRequest configures a sliced execution algorithm for a signal,
+// e.g. {"algo":"twap","duration":"30m","slices":12}.
+type ExecutionRequest struct {
+	Algo     string `json:"algo"`
+	Duration string `json:"duration"`
+	Slices   int    `json:"slices"`
 }
 
-// CreateSignalRequest represents the request to create a new signal
+// CreateSignalRequest represents the request to create a new signal.
+// Price fields are decimal.NullDecimal rather than *float64: parsers build
+// them straight from the source's string/JSON-number representation via
+// decimal.NewFromString, so a symbol like BTCUSDT keeps its full 8 decimal
+// places through TP1/TP2 ordering checks instead of losing them to float
+// rounding.
 type CreateSignalRequest struct {
-	Source     string          `json:"source"`
-	Symbol     string          `json:"symbol"`
-	SignalType string          `json:"signal_type"`
-	Price      *float64        `json:"price,omitempty"`
-	StopLoss   *float64        `json:"stop_loss,omitempty"`
-	TakeProfit *float64        `json:"take_profit,omitempty"`
-	TP1        *float64        `json:"tp1,omitempty"`
-	TP2        *float64        `json:"tp2,omitempty"`
-	SL1        *float64        `json:"sl1,omitempty"`
-	SL2        *float64        `json:"sl2,omitempty"`
-	Payload    json.RawMessage `json:"payload"`
+	Source     string              `json:"source"`
+	Symbol     string              `json:"symbol"`
+	SignalType string              `json:"signal_type"`
+	Price      decimal.NullDecimal `json:"price,omitempty"`
+	StopLoss   decimal.NullDecimal `json:"stop_loss,omitempty"`
+	TakeProfit decimal.NullDecimal `json:"take_profit,omitempty"`
+	TP1        decimal.NullDecimal `json:"tp1,omitempty"`
+	TP2        decimal.NullDecimal `json:"tp2,omitempty"`
+	SL1        decimal.NullDecimal `json:"sl1,omitempty"`
+	SL2        decimal.NullDecimal `json:"sl2,omitempty"`
+	Payload    json.RawMessage     `json:"payload"`
+	// Timestamp echoes the source-reported signal time (RFC3339), used for
+	// idempotency fingerprinting and replay-window checks. Not persisted.
+	Timestamp string `json:"timestamp,omitempty"`
 }
 
 // CreateTradeRequest represents the request to create a new trade
@@ -116,6 +203,7 @@ type CreateTradeRequest struct {
 	SignalID       *int     `json:"signal_id,omitempty"`
 	ParentSignalID *int     `json:"parent_signal_id,omitempty"`
 	ParentTradeID  *int     `json:"parent_trade_id,omitempty"`
+	HedgeOf        *int     `json:"hedge_of,omitempty"`
 	TradeType      string   `json:"trade_type"`
 	Symbol         string   `json:"symbol"`
 	OrderType      string   `json:"order_type"`
@@ -130,6 +218,101 @@ type CreateTradeRequest struct {
 	SL2            *float64 `json:"sl2,omitempty"`
 }
 
+// ExecutionPosition tracks the cumulative progress of a sliced execution
+// algorithm (see internal/execution) against its parent entry trade.
+type ExecutionPosition struct {
+	ID            int        `json:"id" db:"id"`
+	TradeID       int        `json:"trade_id" db:"trade_id"`
+	Algo          string     `json:"algo" db:"algo"`
+	TargetVolume  float64    `json:"target_volume" db:"target_volume"`
+	FilledVolume  float64    `json:"filled_volume" db:"filled_volume"`
+	Status        string     `json:"status" db:"status"` // "running", "completed", "cancelled"
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// CreateExecutionPositionRequest represents the request to start tracking a
+// sliced execution algorithm run.
+type CreateExecutionPositionRequest struct {
+	TradeID      int     `json:"trade_id"`
+	Algo         string  `json:"algo"`
+	TargetVolume float64 `json:"target_volume"`
+}
+
+// RiskGuardState persists the RiskGuard kill-switch's daily baseline (see
+// internal/signals/riskguard.go) so a process restart mid-session doesn't
+// lose track of today's realized P/L, peak equity, or losing streak.
+type RiskGuardState struct {
+	ID                int       `json:"id" db:"id"`
+	TradingDay        time.Time `json:"trading_day" db:"trading_day"`
+	StartingBalance   float64   `json:"starting_balance" db:"starting_balance"`
+	PeakEquity        float64   `json:"peak_equity" db:"peak_equity"`
+	RealizedPnL       float64   `json:"realized_pnl" db:"realized_pnl"`
+	ConsecutiveLosses int       `json:"consecutive_losses" db:"consecutive_losses"`
+	Tripped           bool      `json:"tripped" db:"tripped"`
+	TripReason        string    `json:"trip_reason,omitempty" db:"trip_reason"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateRiskGuardStateRequest represents the request to persist an updated
+// RiskGuard snapshot.
+type UpdateRiskGuardStateRequest struct {
+	PeakEquity        float64
+	RealizedPnL       float64
+	ConsecutiveLosses int
+	Tripped           bool
+	TripReason        string
+}
+
+// BudgetState persists the VolumeGuard's daily accumulated notional volume
+// and per-symbol broker fees (see internal/signals/volumeguard.go) so a
+// process restart mid-session doesn't lose track of today's totals.
+type BudgetState struct {
+	ID                int             `json:"id" db:"id"`
+	TradingDay        time.Time       `json:"trading_day" db:"trading_day"`
+	AccumulatedVolume float64         `json:"accumulated_volume" db:"accumulated_volume"`
+	// AccumulatedFees is a JSON-encoded map[string]float64 of accumulated
+	// commission+swap per symbol, since Postgres has no native map column.
+	AccumulatedFees json.RawMessage `json:"accumulated_fees" db:"accumulated_fees"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateBudgetStateRequest represents the request to persist an updated
+// VolumeGuard snapshot.
+type UpdateBudgetStateRequest struct {
+	AccumulatedVolume float64
+	AccumulatedFees   json.RawMessage
+}
+
+// InstrumentSpec persists a symbol's broker-side precision metadata (see
+// internal/instrument.Catalog) so every process shares the same tick
+// sizes without each hitting mt5.Client.GetSymbolSpecs on its own.
+type InstrumentSpec struct {
+	ID             int       `json:"id" db:"id"`
+	Symbol         string    `json:"symbol" db:"symbol"`
+	PriceTickSize  float64   `json:"price_tick_size" db:"price_tick_size"`
+	VolumeTickSize float64   `json:"volume_tick_size" db:"volume_tick_size"`
+	MinVolume      float64   `json:"min_volume" db:"min_volume"`
+	MaxVolume      float64   `json:"max_volume" db:"max_volume"`
+	ContractSize   float64   `json:"contract_size" db:"contract_size"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpsertInstrumentSpecRequest represents the request to persist a symbol's
+// refreshed precision metadata.
+type UpsertInstrumentSpecRequest struct {
+	Symbol         string
+	PriceTickSize  float64
+	VolumeTickSize float64
+	MinVolume      float64
+	MaxVolume      float64
+	ContractSize   float64
+}
+
 // UpdateTradeStatusRequest represents the request to update trade status
 type UpdateTradeStatusRequest struct {
 	Status       string           `json:"status"`
@@ -137,7 +320,15 @@ type UpdateTradeStatusRequest struct {
 	MT5Response  *json.RawMessage `json:"mt5_response,omitempty"`
 	EntryPrice   *float64         `json:"entry_price,omitempty"`
 	CurrentPrice *float64         `json:"current_price,omitempty"`
-	ProfitLoss   *float64         `json:"profit_loss,omitempty"`
-	Commission   *float64         `json:"commission,omitempty"`
-	Swap         *float64         `json:"swap,omitempty"`
+	// Volume overrides the trade's recorded volume with the actual filled
+	// volume reported by MT5, which can differ from the requested volume
+	// on a partial fill.
+	Volume *float64 `json:"volume,omitempty"`
+	// StopLoss updates the trade's recorded stop-loss, e.g. when a runner
+	// is shifted to breakeven after TP1 fills (see
+	// Processor.shiftToBreakeven).
+	StopLoss   *float64 `json:"stop_loss,omitempty"`
+	ProfitLoss *float64 `json:"profit_loss,omitempty"`
+	Commission *float64 `json:"commission,omitempty"`
+	Swap       *float64 `json:"swap,omitempty"`
 }
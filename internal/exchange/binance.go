@@ -0,0 +1,222 @@
+package exchange
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// BinanceConfig holds the credentials and endpoint for the Binance REST API.
+type BinanceConfig struct {
+	Endpoint  string
+	APIKey    string
+	APISecret string
+}
+
+// Binance implements ExchangeClient against the Binance USDⓈ-M futures API.
+type Binance struct {
+	cfg        BinanceConfig
+	httpClient *http.Client
+}
+
+// NewBinance creates a new Binance adapter.
+func NewBinance(cfg BinanceConfig) *Binance {
+	return &Binance{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the adapter's registered identifier.
+func (b *Binance) Name() string {
+	return "binance"
+}
+
+// sign computes the Binance HMAC-SHA256 query-string signature.
+func (b *Binance) sign(query string) string {
+	mac := hmac.New(sha256.New, []byte(b.cfg.APISecret))
+	mac.Write([]byte(query))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *Binance) doSigned(ctx context.Context, method, path string, params url.Values) ([]byte, error) {
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	query := params.Encode()
+	query += "&signature=" + b.sign(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, b.cfg.Endpoint+path+"?"+query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create binance request: %w", err)
+	}
+	req.Header.Set("X-MBX-APIKEY", b.cfg.APIKey)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("binance request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read binance response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("binance returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// PlaceOrder submits an order via POST /fapi/v1/order.
+func (b *Binance) PlaceOrder(ctx context.Context, order *Order) (*OrderResult, error) {
+	side := "BUY"
+	if order.Side == "sell" {
+		side = "SELL"
+	}
+
+	params := url.Values{}
+	params.Set("symbol", order.Symbol)
+	params.Set("side", side)
+	params.Set("type", binanceOrderType(order.OrderType))
+	params.Set("quantity", strconv.FormatFloat(order.Volume, 'f', -1, 64))
+	if order.OrderType == "limit" {
+		params.Set("price", strconv.FormatFloat(order.Price, 'f', -1, 64))
+		params.Set("timeInForce", "GTC")
+	}
+
+	respBody, err := b.doSigned(ctx, http.MethodPost, "/fapi/v1/order", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		OrderID int64 `json:"orderId"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal binance order response: %w", err)
+	}
+
+	return &OrderResult{
+		OrderID: strconv.FormatInt(parsed.OrderID, 10),
+		Symbol:  order.Symbol,
+		Status:  "submitted",
+		Volume:  order.Volume,
+	}, nil
+}
+
+// CancelOrder cancels an order via DELETE /fapi/v1/order.
+func (b *Binance) CancelOrder(ctx context.Context, orderID string) error {
+	params := url.Values{}
+	params.Set("orderId", orderID)
+
+	_, err := b.doSigned(ctx, http.MethodDelete, "/fapi/v1/order", params)
+	return err
+}
+
+// GetPositions retrieves open positions via GET /fapi/v2/positionRisk.
+func (b *Binance) GetPositions(ctx context.Context) ([]*Position, error) {
+	respBody, err := b.doSigned(ctx, http.MethodGet, "/fapi/v2/positionRisk", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed []struct {
+		Symbol           string `json:"symbol"`
+		PositionAmt      string `json:"positionAmt"`
+		EntryPrice       string `json:"entryPrice"`
+		MarkPrice        string `json:"markPrice"`
+		UnrealizedProfit string `json:"unRealizedProfit"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal binance positions: %w", err)
+	}
+
+	positions := make([]*Position, 0, len(parsed))
+	for _, p := range parsed {
+		amt := parseFloatOrZero(p.PositionAmt)
+		if amt == 0 {
+			continue
+		}
+		side := "buy"
+		if amt < 0 {
+			side = "sell"
+		}
+		positions = append(positions, &Position{
+			Symbol:       p.Symbol,
+			Side:         side,
+			Volume:       amt,
+			EntryPrice:   parseFloatOrZero(p.EntryPrice),
+			CurrentPrice: parseFloatOrZero(p.MarkPrice),
+			Profit:       parseFloatOrZero(p.UnrealizedProfit),
+		})
+	}
+
+	return positions, nil
+}
+
+// GetAccountInfo retrieves balance via GET /fapi/v2/balance.
+func (b *Binance) GetAccountInfo(ctx context.Context) (*AccountInfo, error) {
+	respBody, err := b.doSigned(ctx, http.MethodGet, "/fapi/v2/balance", url.Values{})
+	if err != nil {
+		return &AccountInfo{Connected: false}, err
+	}
+
+	var parsed []struct {
+		Asset   string `json:"asset"`
+		Balance string `json:"balance"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal binance account info: %w", err)
+	}
+
+	info := &AccountInfo{Currency: "USDT", Connected: true}
+	for _, a := range parsed {
+		if a.Asset == "USDT" {
+			info.Balance = parseFloatOrZero(a.Balance)
+			info.Equity = info.Balance
+			break
+		}
+	}
+
+	return info, nil
+}
+
+// IsConnected checks connectivity via the public server-time endpoint.
+func (b *Binance) IsConnected(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.cfg.Endpoint+"/fapi/v1/time", nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// StreamFills is not yet implemented for Binance; the channel is closed
+// immediately until user-data-stream support is added.
+func (b *Binance) StreamFills(ctx context.Context) (<-chan *Fill, error) {
+	ch := make(chan *Fill)
+	close(ch)
+	return ch, nil
+}
+
+func binanceOrderType(orderType string) string {
+	if orderType == "limit" {
+		return "LIMIT"
+	}
+	return "MARKET"
+}
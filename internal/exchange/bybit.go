@@ -0,0 +1,257 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BybitConfig holds the credentials and endpoint for the Bybit v5 REST API.
+type BybitConfig struct {
+	Endpoint   string
+	APIKey     string
+	APISecret  string
+	RecvWindow int // milliseconds
+}
+
+// Bybit implements ExchangeClient against the Bybit v5 unified trading API.
+type Bybit struct {
+	cfg        BybitConfig
+	httpClient *http.Client
+}
+
+// NewBybit creates a new Bybit adapter.
+func NewBybit(cfg BybitConfig) *Bybit {
+	if cfg.RecvWindow == 0 {
+		cfg.RecvWindow = 5000
+	}
+	return &Bybit{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the adapter's registered identifier.
+func (b *Bybit) Name() string {
+	return "bybit"
+}
+
+// sign computes the X-BAPI-SIGN header per Bybit v5 auth:
+// HMAC-SHA256(timestamp+apiKey+recvWindow+queryString or body, secret).
+func (b *Bybit) sign(timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(b.cfg.APISecret))
+	mac.Write([]byte(timestamp + b.cfg.APIKey + strconv.Itoa(b.cfg.RecvWindow) + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *Bybit) doSigned(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, method, b.cfg.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bybit request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-BAPI-API-KEY", b.cfg.APIKey)
+	req.Header.Set("X-BAPI-TIMESTAMP", timestamp)
+	req.Header.Set("X-BAPI-RECV-WINDOW", strconv.Itoa(b.cfg.RecvWindow))
+	payload := string(body)
+	if len(body) == 0 {
+		if i := strings.IndexByte(path, '?'); i >= 0 {
+			payload = path[i+1:]
+		}
+	}
+	req.Header.Set("X-BAPI-SIGN", b.sign(timestamp, payload))
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("bybit request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bybit response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bybit returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// PlaceOrder submits an order via POST /v5/order/create.
+func (b *Bybit) PlaceOrder(ctx context.Context, order *Order) (*OrderResult, error) {
+	side := "Buy"
+	if order.Side == "sell" {
+		side = "Sell"
+	}
+
+	payload := map[string]interface{}{
+		"category":  "linear",
+		"symbol":    order.Symbol,
+		"side":      side,
+		"orderType": orderTypeFor(order.OrderType),
+		"qty":       strconv.FormatFloat(order.Volume, 'f', -1, 64),
+		"price":     strconv.FormatFloat(order.Price, 'f', -1, 64),
+	}
+	if order.Leverage > 0 {
+		payload["leverage"] = strconv.FormatFloat(order.Leverage, 'f', -1, 64)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bybit order: %w", err)
+	}
+
+	respBody, err := b.doSigned(ctx, http.MethodPost, "/v5/order/create", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Result struct {
+			OrderID string `json:"orderId"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bybit order response: %w", err)
+	}
+
+	return &OrderResult{
+		OrderID: parsed.Result.OrderID,
+		Symbol:  order.Symbol,
+		Status:  "submitted",
+		Volume:  order.Volume,
+	}, nil
+}
+
+// CancelOrder cancels an order via POST /v5/order/cancel.
+func (b *Bybit) CancelOrder(ctx context.Context, orderID string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"category": "linear",
+		"orderId":  orderID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal bybit cancel request: %w", err)
+	}
+
+	_, err = b.doSigned(ctx, http.MethodPost, "/v5/order/cancel", body)
+	return err
+}
+
+// GetPositions retrieves open positions via GET /v5/position/list.
+func (b *Bybit) GetPositions(ctx context.Context) ([]*Position, error) {
+	respBody, err := b.doSigned(ctx, http.MethodGet, "/v5/position/list?category=linear", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Result struct {
+			List []struct {
+				Symbol     string `json:"symbol"`
+				Side       string `json:"side"`
+				Size       string `json:"size"`
+				AvgPrice   string `json:"avgPrice"`
+				MarkPrice  string `json:"markPrice"`
+				UnrealPnl  string `json:"unrealisedPnl"`
+			} `json:"list"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bybit positions: %w", err)
+	}
+
+	positions := make([]*Position, 0, len(parsed.Result.List))
+	for _, p := range parsed.Result.List {
+		positions = append(positions, &Position{
+			Symbol:       p.Symbol,
+			Side:         p.Side,
+			Volume:       parseFloatOrZero(p.Size),
+			EntryPrice:   parseFloatOrZero(p.AvgPrice),
+			CurrentPrice: parseFloatOrZero(p.MarkPrice),
+			Profit:       parseFloatOrZero(p.UnrealPnl),
+		})
+	}
+
+	return positions, nil
+}
+
+// GetAccountInfo retrieves wallet balance via GET /v5/account/wallet-balance.
+func (b *Bybit) GetAccountInfo(ctx context.Context) (*AccountInfo, error) {
+	respBody, err := b.doSigned(ctx, http.MethodGet, "/v5/account/wallet-balance?accountType=UNIFIED", nil)
+	if err != nil {
+		return &AccountInfo{Connected: false}, err
+	}
+
+	var parsed struct {
+		Result struct {
+			List []struct {
+				TotalEquity           string `json:"totalEquity"`
+				TotalWalletBalance    string `json:"totalWalletBalance"`
+			} `json:"list"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bybit account info: %w", err)
+	}
+
+	info := &AccountInfo{Currency: "USDT", Connected: true}
+	if len(parsed.Result.List) > 0 {
+		info.Balance = parseFloatOrZero(parsed.Result.List[0].TotalWalletBalance)
+		info.Equity = parseFloatOrZero(parsed.Result.List[0].TotalEquity)
+	}
+
+	return info, nil
+}
+
+// IsConnected checks connectivity via the public server-time endpoint.
+func (b *Bybit) IsConnected(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.cfg.Endpoint+"/v5/market/time", nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// StreamFills is not yet implemented for Bybit; the channel is closed
+// immediately until websocket support is added.
+func (b *Bybit) StreamFills(ctx context.Context) (<-chan *Fill, error) {
+	ch := make(chan *Fill)
+	close(ch)
+	return ch, nil
+}
+
+func orderTypeFor(orderType string) string {
+	if orderType == "limit" {
+		return "Limit"
+	}
+	return "Market"
+}
+
+func parseFloatOrZero(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,79 @@
+// Package exchange defines a broker-agnostic interface for placing and
+// tracking orders on external execution venues (as opposed to the MT5
+// bridge, which has its own dedicated client in internal/mt5).
+package exchange
+
+import (
+	"context"
+	"fmt"
+)
+
+// Order represents a request to open or modify a position on a venue.
+type Order struct {
+	Symbol     string  `json:"symbol"`
+	Side       string  `json:"side"` // "buy", "sell"
+	Volume     float64 `json:"volume"`
+	Price      float64 `json:"price,omitempty"`
+	OrderType  string  `json:"order_type"` // "market", "limit"
+	StopLoss   float64 `json:"stop_loss,omitempty"`
+	TakeProfit float64 `json:"take_profit,omitempty"`
+	ClientID   string  `json:"client_id,omitempty"`
+	// Leverage requests venue-side leverage for this order. Zero leaves
+	// the account's existing/default leverage untouched.
+	Leverage float64 `json:"leverage,omitempty"`
+}
+
+// OrderResult represents the venue's response to placing an order.
+type OrderResult struct {
+	OrderID string  `json:"order_id"`
+	Symbol  string  `json:"symbol"`
+	Status  string  `json:"status"`
+	Price   float64 `json:"price,omitempty"`
+	Volume  float64 `json:"volume,omitempty"`
+}
+
+// Position represents an open position on a venue.
+type Position struct {
+	Symbol       string  `json:"symbol"`
+	Side         string  `json:"side"`
+	Volume       float64 `json:"volume"`
+	EntryPrice   float64 `json:"entry_price"`
+	CurrentPrice float64 `json:"current_price"`
+	Profit       float64 `json:"profit"`
+}
+
+// AccountInfo represents venue account balance/margin state.
+type AccountInfo struct {
+	Balance   float64 `json:"balance"`
+	Equity    float64 `json:"equity"`
+	Currency  string  `json:"currency"`
+	Connected bool    `json:"connected"`
+}
+
+// Fill represents a single execution event streamed from a venue.
+type Fill struct {
+	OrderID  string  `json:"order_id"`
+	Symbol   string  `json:"symbol"`
+	Side     string  `json:"side"`
+	Price    float64 `json:"price"`
+	Volume   float64 `json:"volume"`
+	FilledAt string  `json:"filled_at"`
+}
+
+// ExchangeClient is implemented by every supported execution venue adapter.
+type ExchangeClient interface {
+	// Name returns the adapter's registered identifier, e.g. "bybit".
+	Name() string
+	PlaceOrder(ctx context.Context, order *Order) (*OrderResult, error)
+	CancelOrder(ctx context.Context, orderID string) error
+	GetPositions(ctx context.Context) ([]*Position, error)
+	GetAccountInfo(ctx context.Context) (*AccountInfo, error)
+	IsConnected(ctx context.Context) bool
+	// StreamFills pushes fill events onto the returned channel until ctx is
+	// cancelled, at which point the channel is closed.
+	StreamFills(ctx context.Context) (<-chan *Fill, error)
+}
+
+// ErrUnknownExchange is returned when a symbol or explicit exchange field
+// cannot be matched to a registered adapter.
+var ErrUnknownExchange = fmt.Errorf("exchange: no adapter registered for that name")
@@ -0,0 +1,226 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// OKXConfig holds the credentials and endpoint for the OKX v5 REST API.
+type OKXConfig struct {
+	Endpoint   string
+	APIKey     string
+	APISecret  string
+	Passphrase string
+}
+
+// OKX implements ExchangeClient against the OKX v5 trading API.
+type OKX struct {
+	cfg        OKXConfig
+	httpClient *http.Client
+}
+
+// NewOKX creates a new OKX adapter.
+func NewOKX(cfg OKXConfig) *OKX {
+	return &OKX{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the adapter's registered identifier.
+func (o *OKX) Name() string {
+	return "okx"
+}
+
+// sign computes the OK-ACCESS-SIGN header:
+// base64(HMAC-SHA256(timestamp+method+requestPath+body, secret)).
+func (o *OKX) sign(timestamp, method, path, body string) string {
+	mac := hmac.New(sha256.New, []byte(o.cfg.APISecret))
+	mac.Write([]byte(timestamp + method + path + body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (o *OKX) doSigned(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	req, err := http.NewRequestWithContext(ctx, method, o.cfg.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create okx request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("OK-ACCESS-KEY", o.cfg.APIKey)
+	req.Header.Set("OK-ACCESS-SIGN", o.sign(timestamp, method, path, string(body)))
+	req.Header.Set("OK-ACCESS-TIMESTAMP", timestamp)
+	req.Header.Set("OK-ACCESS-PASSPHRASE", o.cfg.Passphrase)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("okx request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read okx response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("okx returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// PlaceOrder submits an order via POST /api/v5/trade/order.
+func (o *OKX) PlaceOrder(ctx context.Context, order *Order) (*OrderResult, error) {
+	side := "buy"
+	if order.Side == "sell" {
+		side = "sell"
+	}
+
+	payload := map[string]interface{}{
+		"instId":  order.Symbol,
+		"tdMode":  "cross",
+		"side":    side,
+		"ordType": order.OrderType,
+		"sz":      strconv.FormatFloat(order.Volume, 'f', -1, 64),
+		"px":      strconv.FormatFloat(order.Price, 'f', -1, 64),
+	}
+	if order.Leverage > 0 {
+		payload["lever"] = strconv.FormatFloat(order.Leverage, 'f', -1, 64)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal okx order: %w", err)
+	}
+
+	respBody, err := o.doSigned(ctx, http.MethodPost, "/api/v5/trade/order", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Data []struct {
+			OrdID string `json:"ordId"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal okx order response: %w", err)
+	}
+
+	result := &OrderResult{Symbol: order.Symbol, Status: "submitted", Volume: order.Volume}
+	if len(parsed.Data) > 0 {
+		result.OrderID = parsed.Data[0].OrdID
+	}
+
+	return result, nil
+}
+
+// CancelOrder cancels an order via POST /api/v5/trade/cancel-order.
+func (o *OKX) CancelOrder(ctx context.Context, orderID string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"ordId": orderID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal okx cancel request: %w", err)
+	}
+
+	_, err = o.doSigned(ctx, http.MethodPost, "/api/v5/trade/cancel-order", body)
+	return err
+}
+
+// GetPositions retrieves open positions via GET /api/v5/account/positions.
+func (o *OKX) GetPositions(ctx context.Context) ([]*Position, error) {
+	respBody, err := o.doSigned(ctx, http.MethodGet, "/api/v5/account/positions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Data []struct {
+			InstID  string `json:"instId"`
+			PosSide string `json:"posSide"`
+			Pos     string `json:"pos"`
+			AvgPx   string `json:"avgPx"`
+			Last    string `json:"last"`
+			Upl     string `json:"upl"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal okx positions: %w", err)
+	}
+
+	positions := make([]*Position, 0, len(parsed.Data))
+	for _, p := range parsed.Data {
+		positions = append(positions, &Position{
+			Symbol:       p.InstID,
+			Side:         p.PosSide,
+			Volume:       parseFloatOrZero(p.Pos),
+			EntryPrice:   parseFloatOrZero(p.AvgPx),
+			CurrentPrice: parseFloatOrZero(p.Last),
+			Profit:       parseFloatOrZero(p.Upl),
+		})
+	}
+
+	return positions, nil
+}
+
+// GetAccountInfo retrieves balance via GET /api/v5/account/balance.
+func (o *OKX) GetAccountInfo(ctx context.Context) (*AccountInfo, error) {
+	respBody, err := o.doSigned(ctx, http.MethodGet, "/api/v5/account/balance", nil)
+	if err != nil {
+		return &AccountInfo{Connected: false}, err
+	}
+
+	var parsed struct {
+		Data []struct {
+			TotalEq string `json:"totalEq"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal okx account info: %w", err)
+	}
+
+	info := &AccountInfo{Currency: "USDT", Connected: true}
+	if len(parsed.Data) > 0 {
+		info.Equity = parseFloatOrZero(parsed.Data[0].TotalEq)
+		info.Balance = info.Equity
+	}
+
+	return info, nil
+}
+
+// IsConnected checks connectivity via the public system-time endpoint.
+func (o *OKX) IsConnected(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.cfg.Endpoint+"/api/v5/public/time", nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// StreamFills is not yet implemented for OKX; the channel is closed
+// immediately until websocket support is added.
+func (o *OKX) StreamFills(ctx context.Context) (<-chan *Fill, error) {
+	ch := make(chan *Fill)
+	close(ch)
+	return ch, nil
+}
@@ -0,0 +1,178 @@
+// Package execution implements sliced execution algorithms - currently
+// TWAP - that work a large signal into the market as child maker orders
+// over a window instead of firing one market order, to reduce slippage on
+// illiquid symbols. Enable it per-signal via the TradingView webhook's
+// "execution" field, e.g. {"algo":"twap","duration":"30m","slices":12}.
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"trading-system/internal/database"
+	"trading-system/internal/logging"
+	"trading-system/internal/mt5"
+)
+
+// AlgoTWAP slices a signal's volume evenly across Slices child orders, one
+// per Duration/Slices tick.
+const AlgoTWAP = "twap"
+
+// Config describes one execution run, parsed from a
+// database.ExecutionRequest.
+type Config struct {
+	Algo     string
+	Duration time.Duration
+	Slices   int
+}
+
+// ParseConfig validates and converts a webhook-supplied execution request.
+func ParseConfig(req *database.ExecutionRequest) (*Config, error) {
+	if req.Algo != AlgoTWAP {
+		return nil, fmt.Errorf("unsupported execution algo: %q", req.Algo)
+	}
+	if req.Slices <= 0 {
+		return nil, fmt.Errorf("execution.slices must be positive, got %d", req.Slices)
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid execution.duration %q: %w", req.Duration, err)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("execution.duration must be positive, got %s", duration)
+	}
+
+	return &Config{Algo: req.Algo, Duration: duration, Slices: req.Slices}, nil
+}
+
+// Engine runs sliced execution algorithms against the MT5 bridge.
+type Engine struct {
+	mt5 *mt5.Client
+	db  *database.DB
+}
+
+// NewEngine creates an execution Engine.
+func NewEngine(mt5Client *mt5.Client, db *database.DB) *Engine {
+	return &Engine{mt5: mt5Client, db: db}
+}
+
+// Run works trade's full volume over cfg.Duration as cfg.Slices child
+// limit orders resting at the best price (a maker order), cancelling and
+// replacing each slice's order as the tick moves. It terminates early once
+// the target volume is filled, or crosses the spread with a market order
+// for any residual quantity once the deadline passes. Intended to be run
+// in its own goroutine by the caller; ctx cancellation stops it early and
+// leaves any resting order in place.
+func (e *Engine) Run(ctx context.Context, cfg Config, trade *database.Trade) error {
+	logger := logging.FromContext(ctx)
+
+	position, err := e.db.CreateExecutionPosition(ctx, &database.CreateExecutionPositionRequest{
+		TradeID:      trade.ID,
+		Algo:         cfg.Algo,
+		TargetVolume: trade.Volume,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start execution position: %w", err)
+	}
+
+	sliceVolume := trade.Volume / float64(cfg.Slices)
+	tickInterval := cfg.Duration / time.Duration(cfg.Slices)
+	limiter := rate.NewLimiter(rate.Every(tickInterval), 1)
+	deadline := time.Now().Add(cfg.Duration)
+
+	var filled float64
+	var restingTicket int64
+
+	for filled < trade.Volume && time.Now().Before(deadline) {
+		if err := limiter.Wait(ctx); err != nil {
+			logger.Info("twap execution stopped early", "trade_id", trade.ID, "reason", err)
+			break
+		}
+
+		remaining := trade.Volume - filled
+		sliceSize := sliceVolume
+		if sliceSize > remaining {
+			sliceSize = remaining
+		}
+
+		tick, err := e.mt5.GetTick(ctx, trade.Symbol)
+		if err != nil {
+			logger.Error("twap failed to get tick, skipping slice", "trade_id", trade.ID, "error", err)
+			continue
+		}
+
+		if restingTicket != 0 {
+			if err := e.mt5.CancelOrder(ctx, restingTicket); err != nil {
+				logger.Error("twap failed to cancel stale slice order", "trade_id", trade.ID, "ticket", restingTicket, "error", err)
+			}
+		}
+
+		resp, err := e.mt5.SendTrade(ctx, &mt5.TradeRequest{
+			Symbol:    trade.Symbol,
+			Action:    trade.Direction,
+			Volume:    sliceSize,
+			Price:     makerPrice(trade.Direction, tick),
+			OrderType: "limit",
+		})
+		if err != nil {
+			logger.Error("twap slice order failed", "trade_id", trade.ID, "error", err)
+			continue
+		}
+
+		if !resp.Success {
+			continue
+		}
+
+		restingTicket = resp.Ticket
+		filled += resp.Volume
+		if err := e.db.UpdateExecutionPositionFilled(ctx, position.ID, filled, "running"); err != nil {
+			logger.Error("twap failed to persist fill progress", "trade_id", trade.ID, "error", err)
+		}
+	}
+
+	status := "completed"
+	if filled < trade.Volume {
+		if restingTicket != 0 {
+			if err := e.mt5.CancelOrder(ctx, restingTicket); err != nil {
+				logger.Error("twap failed to cancel final resting order", "trade_id", trade.ID, "ticket", restingTicket, "error", err)
+			}
+		}
+
+		residual := trade.Volume - filled
+		logger.Info("twap crossing spread for residual volume at deadline", "trade_id", trade.ID, "residual", residual)
+
+		resp, err := e.mt5.SendTrade(ctx, &mt5.TradeRequest{
+			Symbol:    trade.Symbol,
+			Action:    trade.Direction,
+			Volume:    residual,
+			OrderType: "market",
+		})
+		if err != nil || !resp.Success {
+			status = "cancelled"
+			logger.Error("twap residual market fallback failed", "trade_id", trade.ID, "error", err)
+		} else {
+			filled += resp.Volume
+		}
+	}
+
+	if err := e.db.UpdateExecutionPositionFilled(ctx, position.ID, filled, status); err != nil {
+		return fmt.Errorf("failed to persist final execution state: %w", err)
+	}
+
+	logger.Info("twap execution finished", "trade_id", trade.ID, "status", status, "filled", filled, "target", trade.Volume)
+	return nil
+}
+
+// makerPrice returns the resting price for a slice's limit order: join the
+// bid when buying, the ask when selling, so the slice posts as a maker
+// order rather than crossing the spread.
+func makerPrice(direction string, tick *mt5.TickInfo) float64 {
+	if direction == "sell" {
+		return tick.Ask
+	}
+	return tick.Bid
+}
@@ -0,0 +1,185 @@
+// Package instrument resolves per-symbol broker precision (price tick size,
+// volume step, min/max volume) and snaps order prices/volumes onto valid
+// ticks before they ever reach mt5.Client. Without this, a signal computed
+// in floating point (e.g. a DCA leg price or a volume-scaled entry) can
+// drift off the broker's tick grid and get silently rejected or re-rounded
+// by MT5 in a way we never observe.
+package instrument
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"trading-system/internal/database"
+	"trading-system/internal/mt5"
+)
+
+// ErrZeroVolume is returned by Normalize when a volume rounds down to zero
+// and the catalog is running in strict mode (see Catalog.strict).
+var ErrZeroVolume = errors.New("instrument: normalized volume is zero")
+
+// TickSize holds a symbol's broker-side precision and contract metadata.
+type TickSize struct {
+	PriceTickSize  float64
+	VolumeTickSize float64
+	MinVolume      float64
+	MaxVolume      float64
+	ContractSize   float64
+}
+
+type cachedSpec struct {
+	tick      TickSize
+	fetchedAt time.Time
+}
+
+// Catalog caches per-symbol TickSize metadata and normalizes order prices
+// and volumes onto it. Specs are refreshed from MT5 (and mirrored to the
+// instrument_specs table) on a TTL, falling back to the DB cache when MT5
+// is unreachable.
+type Catalog struct {
+	mt5 *mt5.Client
+	db  *database.DB
+	ttl time.Duration
+
+	// strict controls what Normalize does when a volume rounds to zero:
+	// true returns ErrZeroVolume, false auto-corrects up to MinVolume.
+	strict bool
+
+	mu    sync.Mutex
+	specs map[string]cachedSpec
+}
+
+// NewCatalog constructs a Catalog. strict should be cfg.Instrument.StrictPrecision == "strict".
+func NewCatalog(mt5Client *mt5.Client, db *database.DB, ttl time.Duration, strict bool) *Catalog {
+	return &Catalog{
+		mt5:    mt5Client,
+		db:     db,
+		ttl:    ttl,
+		strict: strict,
+		specs:  make(map[string]cachedSpec),
+	}
+}
+
+// Normalize snaps price to the nearest valid tick (round-half-to-even) and
+// volume down to the nearest valid step, clamped to [MinVolume, MaxVolume].
+// If the stepped volume is zero, it returns ErrZeroVolume in strict mode or
+// auto-corrects to MinVolume otherwise.
+func (c *Catalog) Normalize(ctx context.Context, symbol string, price, volume float64) (float64, float64, error) {
+	tick, err := c.specFor(ctx, symbol)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to resolve tick size for %s: %w", symbol, err)
+	}
+
+	normPrice := roundToTick(price, tick.PriceTickSize)
+
+	normVolume := volume
+	if tick.VolumeTickSize > 0 {
+		normVolume = math.Floor(volume/tick.VolumeTickSize) * tick.VolumeTickSize
+	}
+	if tick.MaxVolume > 0 && normVolume > tick.MaxVolume {
+		normVolume = tick.MaxVolume
+	}
+	if normVolume <= 0 && c.strict {
+		return 0, 0, ErrZeroVolume
+	}
+	if normVolume < tick.MinVolume {
+		normVolume = tick.MinVolume
+	}
+
+	return normPrice, normVolume, nil
+}
+
+// specFor returns symbol's cached TickSize, refreshing it from MT5 (and
+// persisting the refresh to instrument_specs) once the cached entry is
+// older than c.ttl. A stale DB row is used as a fallback if the MT5 refresh
+// itself fails.
+func (c *Catalog) specFor(ctx context.Context, symbol string) (TickSize, error) {
+	c.mu.Lock()
+	cached, ok := c.specs[symbol]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < c.ttl {
+		return cached.tick, nil
+	}
+
+	tick, err := c.refresh(ctx, symbol)
+	if err == nil {
+		c.mu.Lock()
+		c.specs[symbol] = cachedSpec{tick: tick, fetchedAt: time.Now()}
+		c.mu.Unlock()
+		return tick, nil
+	}
+
+	if dbSpec, dbErr := c.db.GetInstrumentSpec(ctx, symbol); dbErr == nil {
+		tick := TickSize{
+			PriceTickSize:  dbSpec.PriceTickSize,
+			VolumeTickSize: dbSpec.VolumeTickSize,
+			MinVolume:      dbSpec.MinVolume,
+			MaxVolume:      dbSpec.MaxVolume,
+			ContractSize:   dbSpec.ContractSize,
+		}
+		c.mu.Lock()
+		c.specs[symbol] = cachedSpec{tick: tick, fetchedAt: time.Now()}
+		c.mu.Unlock()
+		return tick, nil
+	}
+
+	if ok {
+		// MT5 and the DB are both unavailable - prefer a stale cached spec
+		// over failing the trade outright.
+		return cached.tick, nil
+	}
+
+	return TickSize{}, err
+}
+
+// refresh pulls fresh specs for every symbol from MT5 and mirrors them into
+// instrument_specs, returning the entry for symbol.
+func (c *Catalog) refresh(ctx context.Context, symbol string) (TickSize, error) {
+	specs, err := c.mt5.GetSymbolSpecs(ctx)
+	if err != nil {
+		return TickSize{}, err
+	}
+
+	var found *mt5.SymbolSpec
+	for _, spec := range specs {
+		err := c.db.UpsertInstrumentSpec(ctx, &database.UpsertInstrumentSpecRequest{
+			Symbol:         spec.Symbol,
+			PriceTickSize:  spec.PriceTickSize,
+			VolumeTickSize: spec.VolumeTickSize,
+			MinVolume:      spec.MinVolume,
+			MaxVolume:      spec.MaxVolume,
+			ContractSize:   spec.ContractSize,
+		})
+		if err != nil {
+			return TickSize{}, fmt.Errorf("failed to cache instrument spec for %s: %w", spec.Symbol, err)
+		}
+		if spec.Symbol == symbol {
+			found = spec
+		}
+	}
+	if found == nil {
+		return TickSize{}, fmt.Errorf("symbol %s not found in MT5 symbol specs", symbol)
+	}
+
+	return TickSize{
+		PriceTickSize:  found.PriceTickSize,
+		VolumeTickSize: found.VolumeTickSize,
+		MinVolume:      found.MinVolume,
+		MaxVolume:      found.MaxVolume,
+		ContractSize:   found.ContractSize,
+	}, nil
+}
+
+// roundToTick snaps value to the nearest multiple of tick using
+// round-half-to-even, matching how MT5 itself rounds prices onto the tick
+// grid. A non-positive tick leaves value unchanged.
+func roundToTick(value, tick float64) float64 {
+	if tick <= 0 {
+		return value
+	}
+	return math.RoundToEven(value/tick) * tick
+}
@@ -0,0 +1,95 @@
+// Package logging provides the structured (JSON or text) logger shared by
+// the server, signal processor, and MT5 client, along with per-request
+// correlation IDs that tie a single TradingView alert's log lines together
+// from webhook ingress through MT5 submission.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/google/uuid"
+
+	"trading-system/internal/config"
+)
+
+type correlationIDKeyType struct{}
+
+var correlationIDKey = correlationIDKeyType{}
+
+// level is shared by every Logger built by Init, so SetLevel can hot-reload
+// verbosity at runtime without reconstructing handlers.
+var level = new(slog.LevelVar)
+
+var defaultLogger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level}))
+
+// Init builds the process-wide structured logger from LoggingConfig,
+// honoring Format ("json" or "text") and Level. Call once at startup.
+func Init(cfg config.LoggingConfig) *slog.Logger {
+	if err := SetLevel(cfg.Level); err != nil {
+		level.Set(slog.LevelInfo)
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+
+	defaultLogger = slog.New(handler)
+	slog.SetDefault(defaultLogger)
+	return defaultLogger
+}
+
+// SetLevel hot-reloads the minimum log level ("debug", "info", "warn", or
+// "error") shared by every Logger returned from Default/FromContext.
+func SetLevel(levelName string) error {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(levelName)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", levelName, err)
+	}
+	level.Set(parsed)
+	return nil
+}
+
+// Default returns the process-wide logger configured by Init.
+func Default() *slog.Logger {
+	return defaultLogger
+}
+
+// WithCorrelationID generates a new correlation ID and returns a context
+// carrying it, along with the ID itself (e.g. to stamp onto an HTTP
+// response header or the stored SystemLog row).
+func WithCorrelationID(ctx context.Context) (context.Context, string) {
+	id := uuid.New().String()
+	return context.WithValue(ctx, correlationIDKey, id), id
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or "" if none
+// was attached via WithCorrelationID.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey).(string)
+	return id
+}
+
+// ContextWithCorrelationID attaches a known correlation ID to ctx. Unlike
+// WithCorrelationID it does not mint a new one - use it to re-establish the
+// correlation ID carried in a signal's stored payload once processing
+// resumes on the background signalProcessingLoop, outside the original
+// HTTP request's context.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, id)
+}
+
+// FromContext returns the default logger with the context's correlation ID
+// (if any) already attached, so every downstream log line carries it.
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := CorrelationID(ctx); id != "" {
+		return defaultLogger.With("correlation_id", id)
+	}
+	return defaultLogger
+}
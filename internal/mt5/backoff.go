@@ -0,0 +1,40 @@
+package mt5
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff computes the delay before retry attempt N (0-indexed). It's
+// pluggable so tests can substitute a zero-delay policy without sleeping
+// through real retry windows.
+type Backoff interface {
+	NextDelay(attempt int) time.Duration
+}
+
+// FullJitterBackoff implements the "full jitter" policy: delay = rand(0,
+// min(Cap, Base*2^attempt)). Spreading retries uniformly across the whole
+// window, rather than a fixed or half-jittered delay, avoids the
+// synchronized retry storms a fixed retryDelay produces when the bridge
+// briefly drops every in-flight call at once.
+type FullJitterBackoff struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// NextDelay implements Backoff.
+func (b *FullJitterBackoff) NextDelay(attempt int) time.Duration {
+	if b.Base <= 0 {
+		return 0
+	}
+
+	exp := b.Base * time.Duration(int64(1)<<uint(attempt))
+	if exp <= 0 || (b.Cap > 0 && exp > b.Cap) {
+		exp = b.Cap
+	}
+	if exp <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(exp) + 1))
+}
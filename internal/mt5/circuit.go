@@ -0,0 +1,129 @@
+package mt5
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitState is the circuit breaker's current state.
+type CircuitState int
+
+const (
+	// CircuitClosed is the normal state: calls pass through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means the bridge has failed Threshold times in a row;
+	// calls are short-circuited until Cooldown elapses.
+	CircuitOpen
+	// CircuitHalfOpen means Cooldown has elapsed and a single probe call
+	// is being let through to test whether the bridge has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker trips open after Threshold consecutive call failures,
+// then lets a single half-open probe through every Cooldown to test
+// whether the bridge has recovered - closing again on its success, or
+// re-opening (restarting the cooldown) on its failure.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu            sync.Mutex
+	failures      int
+	state         CircuitState
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// newCircuitBreaker creates a circuitBreaker. threshold <= 0 disables it -
+// Allow always returns true.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a call should proceed: always true when closed or
+// disabled, true for exactly one half-open probe per cooldown window once
+// open, false otherwise.
+func (b *circuitBreaker) Allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitClosed:
+		return true
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown || b.probeInFlight {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.probeInFlight = true
+		return true
+	default: // CircuitHalfOpen
+		return false
+	}
+}
+
+// RecordSuccess resets the breaker to closed.
+func (b *circuitBreaker) RecordSuccess() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.state = CircuitClosed
+	b.probeInFlight = false
+}
+
+// RecordFailure counts a failed call, tripping the breaker open once
+// Threshold consecutive failures accumulate, or re-opening it immediately
+// if the half-open probe itself failed.
+func (b *circuitBreaker) RecordFailure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probeInFlight = false
+
+	if b.state == CircuitHalfOpen {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// State returns the breaker's current state.
+func (b *circuitBreaker) State() CircuitState {
+	if b.threshold <= 0 {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
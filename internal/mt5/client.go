@@ -3,7 +3,10 @@ package mt5
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +15,18 @@ import (
 	"trading-system/internal/config"
 )
 
+// ErrMT5Disconnected indicates the MT5 bridge could not be reached at all
+// (connection refused, DNS failure, non-2xx response, etc.), as opposed to
+// a request that timed out against the caller's or client's own deadline
+// (see context.DeadlineExceeded). Callers use errors.Is to distinguish the
+// two, e.g. to return 503 vs 504 from the HTTP API.
+var ErrMT5Disconnected = errors.New("mt5 bridge unavailable")
+
+// ErrCircuitOpen is returned by doWithRetry without attempting any HTTP
+// call when the circuit breaker is open, so a bridge outage doesn't burn a
+// full retry budget on every signal while it's down.
+var ErrCircuitOpen = errors.New("mt5 circuit breaker open")
+
 // Client handles communication with MT5 via HTTP bridge
 type Client struct {
 	baseURL    string
@@ -19,10 +34,21 @@ type Client struct {
 	timeout    time.Duration
 	retries    int
 	retryDelay time.Duration
+	backoff    Backoff
+	circuit    *circuitBreaker
 }
 
 // NewClient creates a new MT5 client
 func NewClient(cfg *config.MT5Config) *Client {
+	baseMs := cfg.BackoffBaseMs
+	if baseMs <= 0 {
+		baseMs = cfg.RetryDelayMs
+	}
+	capMs := cfg.BackoffCapMs
+	if capMs <= 0 {
+		capMs = cfg.RetryDelayMs * cfg.RetryAttempts
+	}
+
 	return &Client{
 		baseURL: cfg.Endpoint,
 		httpClient: &http.Client{
@@ -31,6 +57,11 @@ func NewClient(cfg *config.MT5Config) *Client {
 		timeout:    time.Duration(cfg.TimeoutSeconds) * time.Second,
 		retries:    cfg.RetryAttempts,
 		retryDelay: time.Duration(cfg.RetryDelayMs) * time.Millisecond,
+		backoff: &FullJitterBackoff{
+			Base: time.Duration(baseMs) * time.Millisecond,
+			Cap:  time.Duration(capMs) * time.Millisecond,
+		},
+		circuit: newCircuitBreaker(cfg.CircuitThreshold, time.Duration(cfg.CircuitCooldownMs)*time.Millisecond),
 	}
 }
 
@@ -47,6 +78,12 @@ type TradeRequest struct {
 	OrderType  string  `json:"order_type"` // "market", "limit", "stop"
 	Comment    string  `json:"comment,omitempty"`
 	Magic      int     `json:"magic,omitempty"` // EA magic number
+	// IdempotencyKey lets the bridge recognize a leg it already executed if
+	// doWithRetry resends the whole batch after a lost response, instead of
+	// opening the position twice. SendTradeBatch fills it in when empty;
+	// SendTrade's single-leg path leaves it blank since doWithRetry already
+	// covers that case by retrying the full POST, not a partial batch.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // TradeResponse represents MT5 trade execution response
@@ -68,6 +105,27 @@ type TradeResponse struct {
 	TP2Ticket            int64   `json:"tp2_ticket,omitempty"`           // TP2 position ticket
 }
 
+// PositionModifyRequest requests a change to an existing position's
+// stop-loss/take-profit, or a partial close at a given TP level.
+type PositionModifyRequest struct {
+	PositionTicket int64    `json:"position_ticket"`
+	Symbol         string   `json:"symbol"`
+	TakeProfit     float64  `json:"take_profit,omitempty"`
+	StopLoss       *float64 `json:"stop_loss,omitempty"`
+	PartialVolume  float64  `json:"partial_volume,omitempty"` // volume to close at TakeProfit, 0 = modify only
+	TPType         string   `json:"tp_type,omitempty"`        // "tp1" or "tp2", for bridge-side bookkeeping
+}
+
+// PositionModifyResponse represents the MT5 bridge's response to a
+// PositionModifyRequest.
+type PositionModifyResponse struct {
+	Success       bool    `json:"success"`
+	TPOrderTicket int64   `json:"tp_order_ticket,omitempty"`
+	ErrorCode     int     `json:"error_code,omitempty"`
+	ErrorMsg      string  `json:"error_msg,omitempty"`
+	Commission    float64 `json:"commission,omitempty"`
+}
+
 // OrderInfo represents pending order information
 type OrderInfo struct {
 	Ticket     int64   `json:"ticket"`
@@ -98,6 +156,14 @@ type PositionInfo struct {
 	OpenTime   string  `json:"open_time"`
 }
 
+// TickInfo represents the current best bid/ask for a symbol.
+type TickInfo struct {
+	Symbol string  `json:"symbol"`
+	Bid    float64 `json:"bid"`
+	Ask    float64 `json:"ask"`
+	Time   string  `json:"time"`
+}
+
 // AccountInfo represents MT5 account information
 type AccountInfo struct {
 	Balance    float64 `json:"balance"`
@@ -109,120 +175,231 @@ type AccountInfo struct {
 	Connected  bool    `json:"connected"`
 }
 
-// SendTrade sends a trade request to MT5
-func (c *Client) SendTrade(ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
-	var lastErr error
-	
-	for attempt := 0; attempt <= c.retries; attempt++ {
-		resp, err := c.sendTradeRequest(ctx, req)
-		if err == nil {
-			return resp, nil
-		}
-		
-		lastErr = err
-		if attempt < c.retries {
-			time.Sleep(c.retryDelay)
-		}
-	}
-	
-	return nil, fmt.Errorf("failed to send trade after %d attempts: %w", c.retries+1, lastErr)
+// httpStatusError carries the response status code through doRequest so
+// doWithRetry can classify it as retryable (5xx, 429) or not (other 4xx -
+// a validation error that will fail identically on every retry).
+type httpStatusError struct {
+	StatusCode int
+	Body       string
 }
 
-// sendTradeRequest performs a single trade request
-func (c *Client) sendTradeRequest(ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal trade request: %w", err)
-	}
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("MT5 bridge returned status %d: %s", e.StatusCode, e.Body)
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/trade", bytes.NewBuffer(jsonData))
+// doRequest issues a single HTTP request to the MT5 bridge bounded by
+// callCtx and returns the raw response body. Non-2xx responses are
+// returned as *httpStatusError (not ErrMT5Disconnected) since the bridge
+// did respond - classification into ErrMT5Disconnected / context.DeadlineExceeded
+// happens one layer up, in doWithRetry.
+func (c *Client) doRequest(callCtx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(callCtx, method, c.baseURL+path, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
 	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("MT5 bridge returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
-	var tradeResp TradeResponse
-	if err := json.Unmarshal(body, &tradeResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal trade response: %w", err)
+	return respBody, nil
+}
+
+// isRetryable reports whether err is worth another attempt: network
+// errors (anything that isn't a classified HTTP status) and 5xx/429
+// responses are; any other 4xx is a validation error that will fail
+// identically every time, so it isn't.
+func isRetryable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
 	}
+	return true
+}
 
-	return &tradeResp, nil
+// doWithRetry runs fn against a sequence of per-call deadlines derived from
+// ctx and c.timeout, retrying up to c.retries times with full-jitter
+// exponential backoff (see Backoff) if fn fails with a retryable error. It
+// stops immediately, without retrying, once ctx itself is done - a caller
+// who has already timed out or cancelled gains nothing from further
+// attempts - or once fn fails with a non-retryable error (a 4xx validation
+// error repeats identically on every attempt). A tripped circuit breaker
+// short-circuits the call entirely, without spending a single HTTP round
+// trip. The returned error is classified so callers can distinguish a
+// deadline from a bridge that is simply unreachable (see ErrMT5Disconnected).
+func (c *Client) doWithRetry(ctx context.Context, fn func(callCtx context.Context) error) error {
+	if !c.circuit.Allow() {
+		return ErrCircuitOpen
+	}
+
+	var lastErr error
+
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		err := fn(callCtx)
+		cancel()
+
+		if err == nil {
+			c.circuit.RecordSuccess()
+			return nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil || !isRetryable(err) {
+			break
+		}
+		if attempt < c.retries {
+			time.Sleep(c.backoff.NextDelay(attempt))
+		}
+	}
+
+	c.circuit.RecordFailure()
+	return classifyError(lastErr)
 }
 
-// GetPositions retrieves all open positions
-func (c *Client) GetPositions(ctx context.Context) ([]*PositionInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/positions", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// classifyError normalizes a failed call's error so callers can tell a
+// deadline (context.DeadlineExceeded, possibly worth retrying later) apart
+// from the bridge being unreachable (ErrMT5Disconnected).
+func classifyError(err error) error {
+	if err == nil {
+		return nil
 	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+	return fmt.Errorf("%w: %v", ErrMT5Disconnected, err)
+}
 
-	resp, err := c.httpClient.Do(req)
+// SendTrade sends a trade request to MT5
+func (c *Client) SendTrade(ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
+	jsonData, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, fmt.Errorf("failed to marshal trade request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	var tradeResp TradeResponse
+	err = c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "POST", "/trade", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &tradeResp)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to send trade: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("MT5 bridge returned status %d: %s", resp.StatusCode, string(body))
-	}
+	return &tradeResp, nil
+}
 
-	var positions []*PositionInfo
-	if err := json.Unmarshal(body, &positions); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal positions: %w", err)
-	}
+// TradeBatchRequest is the envelope posted to the bridge's /trade-batch
+// endpoint, mirroring the {category, request: [...]} shape used by
+// exchanges' batch order APIs: Category groups the legs for the bridge's
+// own bookkeeping (e.g. "hedge", "tp-split"), Requests is the ordered list
+// of legs to submit.
+type TradeBatchRequest struct {
+	Category string          `json:"category"`
+	Requests []*TradeRequest `json:"request"`
+}
 
-	return positions, nil
+// tradeIdempotencyKey fingerprints a trade leg so the bridge can recognize
+// and skip a leg it already executed, if doWithRetry resends the batch
+// after a response was lost to a timeout rather than an actual failure.
+func tradeIdempotencyKey(req *TradeRequest) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%f|%s|%f|%d", req.Symbol, req.Action, req.Volume, req.OrderType, req.Price, req.Magic)
+	return hex.EncodeToString(h.Sum(nil))
 }
 
-// GetPositionCount retrieves the number of open positions efficiently
-func (c *Client) GetPositionCount(ctx context.Context) (int, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/position-count", nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+// SendTradeBatch submits multiple trade legs in a single bridge round trip
+// - atomic multi-leg strategies (hedges, TP1/TP2/SL splits) and fanning out
+// GetUnprocessedSignals both pay one HTTP call instead of one per leg. Each
+// leg reports its own success/failure independently: a rejected leg does
+// not fail the whole batch, so the returned slice is always aligned 1:1
+// with requests by index and callers must check each TradeResponse.Success
+// rather than relying on the returned error for per-leg outcomes. The
+// returned error is only non-nil when the batch itself could not be
+// submitted or decoded (bridge unreachable, malformed response).
+func (c *Client) SendTradeBatch(ctx context.Context, category string, requests []*TradeRequest) ([]*TradeResponse, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	for _, req := range requests {
+		if req.IdempotencyKey == "" {
+			req.IdempotencyKey = tradeIdempotencyKey(req)
+		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	jsonData, err := json.Marshal(&TradeBatchRequest{Category: category, Requests: requests})
 	if err != nil {
-		return 0, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, fmt.Errorf("failed to marshal trade batch request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	var tradeResps []*TradeResponse
+	err = c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "POST", "/trade-batch", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &tradeResps)
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to send trade batch: %w", err)
+	}
+	if len(tradeResps) != len(requests) {
+		return nil, fmt.Errorf("trade batch response has %d legs, expected %d", len(tradeResps), len(requests))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("MT5 bridge returned status %d: %s", resp.StatusCode, string(body))
+	return tradeResps, nil
+}
+
+// GetPositions retrieves all open positions
+func (c *Client) GetPositions(ctx context.Context) ([]*PositionInfo, error) {
+	var positions []*PositionInfo
+	err := c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "GET", "/positions", nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &positions)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
 	}
 
+	return positions, nil
+}
+
+// GetPositionCount retrieves the number of open positions efficiently
+func (c *Client) GetPositionCount(ctx context.Context) (int, error) {
 	var countResp struct {
 		Count     int    `json:"count"`
 		Timestamp string `json:"timestamp"`
 	}
-	if err := json.Unmarshal(body, &countResp); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal position count: %w", err)
+	err := c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "GET", "/position-count", nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &countResp)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get position count: %w", err)
 	}
 
 	return countResp.Count, nil
@@ -230,32 +407,42 @@ func (c *Client) GetPositionCount(ctx context.Context) (int, error) {
 
 // GetAccountInfo retrieves account information
 func (c *Client) GetAccountInfo(ctx context.Context) (*AccountInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/account", nil)
+	var account AccountInfo
+	err := c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "GET", "/account", nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &account)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to get account info: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer resp.Body.Close()
+	return &account, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// ModifyPosition sets or updates a position's TP/SL, or schedules a partial
+// close at a TP level, via the bridge's /position/modify endpoint.
+func (c *Client) ModifyPosition(ctx context.Context, req *PositionModifyRequest) (*PositionModifyResponse, error) {
+	jsonData, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to marshal position modify request: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("MT5 bridge returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var account AccountInfo
-	if err := json.Unmarshal(body, &account); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal account info: %w", err)
+	var modifyResp PositionModifyResponse
+	err = c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "POST", "/position/modify", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &modifyResp)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to modify position: %w", err)
 	}
 
-	return &account, nil
+	return &modifyResp, nil
 }
 
 // ClosePosition closes a specific position by ticket
@@ -264,85 +451,130 @@ func (c *Client) ClosePosition(ctx context.Context, ticket int64) (*TradeRespons
 		Action: "close",
 		Magic:  int(ticket),
 	}
-	
+
 	return c.SendTrade(ctx, req)
 }
 
-// IsConnected checks if MT5 bridge is available
+// IsConnected checks if MT5 bridge is available within a single timeout
+// window derived from ctx. It intentionally does not retry: callers poll
+// it frequently, and a transient failure will be observed again on the
+// next poll.
 func (c *Client) IsConnected(ctx context.Context) bool {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/health", nil)
-	if err != nil {
-		return false
-	}
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return false
-	}
-	defer resp.Body.Close()
+	_, err := c.doRequest(callCtx, "GET", "/health", nil)
+	return err == nil
+}
 
-	return resp.StatusCode == http.StatusOK
+// State reports the circuit breaker's current state, so a caller like
+// signals.Processor can short-circuit trade submission entirely - and
+// record a signal as skipped rather than failed - instead of discovering
+// the bridge is down only after doWithRetry burns its retry budget.
+func (c *Client) State() CircuitState {
+	return c.circuit.State()
 }
 
 // GetOrders retrieves all pending orders
 func (c *Client) GetOrders(ctx context.Context) ([]*OrderInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/orders", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	var orders []*OrderInfo
+	err := c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "GET", "/orders", nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &orders)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, fmt.Errorf("failed to get orders: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+	return orders, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("MT5 bridge returned status %d: %s", resp.StatusCode, string(body))
-	}
+// SymbolSpec represents a symbol's broker-side precision and contract
+// metadata, used by internal/instrument.Catalog to round order prices and
+// volumes onto valid ticks before submission.
+type SymbolSpec struct {
+	Symbol         string  `json:"symbol"`
+	PriceTickSize  float64 `json:"price_tick_size"`
+	VolumeTickSize float64 `json:"volume_tick_size"`
+	MinVolume      float64 `json:"min_volume"`
+	MaxVolume      float64 `json:"max_volume"`
+	ContractSize   float64 `json:"contract_size"`
+}
 
-	var orders []*OrderInfo
-	if err := json.Unmarshal(body, &orders); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal orders: %w", err)
+// GetSymbolSpecs retrieves precision and contract metadata for every symbol
+// the bridge knows about.
+func (c *Client) GetSymbolSpecs(ctx context.Context) ([]*SymbolSpec, error) {
+	var specs []*SymbolSpec
+	err := c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "GET", "/symbols", nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &specs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get symbol specs: %w", err)
 	}
 
-	return orders, nil
+	return specs, nil
 }
 
-// GetOrderCount retrieves the number of pending orders efficiently
-func (c *Client) GetOrderCount(ctx context.Context) (int, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/order-count", nil)
+// GetTick retrieves the current best bid/ask for symbol, used by execution
+// algorithms (see internal/execution) to price maker limit orders.
+func (c *Client) GetTick(ctx context.Context, symbol string) (*TickInfo, error) {
+	var tick TickInfo
+	err := c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "GET", "/tick?symbol="+symbol, nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &tick)
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to get tick for %s: %w", symbol, err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	return &tick, nil
+}
+
+// CancelOrder cancels a pending order by ticket, e.g. to replace a maker
+// limit order at a new price during TWAP/VWAP execution.
+func (c *Client) CancelOrder(ctx context.Context, ticket int64) error {
+	jsonData, err := json.Marshal(map[string]int64{"ticket": ticket})
 	if err != nil {
-		return 0, fmt.Errorf("HTTP request failed: %w", err)
+		return fmt.Errorf("failed to marshal cancel request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	err = c.doWithRetry(ctx, func(callCtx context.Context) error {
+		_, err := c.doRequest(callCtx, "POST", "/order/cancel", bytes.NewBuffer(jsonData))
+		return err
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to cancel order %d: %w", ticket, err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("MT5 bridge returned status %d: %s", resp.StatusCode, string(body))
-	}
+	return nil
+}
 
+// GetOrderCount retrieves the number of pending orders efficiently
+func (c *Client) GetOrderCount(ctx context.Context) (int, error) {
 	var countResp struct {
 		Count     int    `json:"count"`
 		Timestamp string `json:"timestamp"`
 	}
-	if err := json.Unmarshal(body, &countResp); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal order count: %w", err)
+	err := c.doWithRetry(ctx, func(callCtx context.Context) error {
+		body, err := c.doRequest(callCtx, "GET", "/order-count", nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &countResp)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get order count: %w", err)
 	}
 
 	return countResp.Count, nil
-} 
\ No newline at end of file
+}
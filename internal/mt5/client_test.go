@@ -0,0 +1,116 @@
+package mt5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"trading-system/internal/config"
+)
+
+func testClient(t *testing.T, server *httptest.Server, cfg *config.MT5Config) *Client {
+	t.Helper()
+	cfg.Endpoint = server.URL
+	c := NewClient(cfg)
+	// Cap real sleeping at a millisecond - the backoff policy itself is covered by
+	// TestFullJitterBackoff, this just needs the retry loop to run fast.
+	c.backoff = &FullJitterBackoff{Base: time.Microsecond, Cap: time.Millisecond}
+	return c
+}
+
+func TestSendTradeRetriesUntilSuccess(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success": true, "ticket": 123}`))
+	}))
+	defer server.Close()
+
+	c := testClient(t, server, &config.MT5Config{
+		TimeoutSeconds: 5,
+		RetryAttempts:  3,
+		RetryDelayMs:   1,
+	})
+
+	resp, err := c.SendTrade(context.Background(), &TradeRequest{Symbol: "EURUSD", Action: "buy", Volume: 0.1})
+	if err != nil {
+		t.Fatalf("expected success after retries, got error: %v", err)
+	}
+	if resp.Ticket != 123 {
+		t.Fatalf("expected ticket 123, got %d", resp.Ticket)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 calls (2 failures + 1 success), got %d", got)
+	}
+}
+
+func TestSendTradeDoesNotRetryClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	c := testClient(t, server, &config.MT5Config{
+		TimeoutSeconds: 5,
+		RetryAttempts:  3,
+		RetryDelayMs:   1,
+	})
+
+	_, err := c.SendTrade(context.Background(), &TradeRequest{Symbol: "EURUSD", Action: "buy", Volume: 0.1})
+	if err == nil {
+		t.Fatal("expected an error for a 400 response")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected exactly 1 call for a non-retryable 4xx, got %d", got)
+	}
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := testClient(t, server, &config.MT5Config{
+		TimeoutSeconds:    5,
+		RetryAttempts:     0,
+		RetryDelayMs:      1,
+		CircuitThreshold:  2,
+		CircuitCooldownMs: 50,
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetAccountInfo(context.Background()); err == nil {
+			t.Fatal("expected an error from the failing bridge")
+		}
+	}
+
+	if c.State() != CircuitOpen {
+		t.Fatalf("expected circuit to be open after %d consecutive failures, got %s", 2, c.State())
+	}
+
+	if _, err := c.GetAccountInfo(context.Background()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen while breaker is open, got %v", err)
+	}
+}
+
+func TestFullJitterBackoff(t *testing.T) {
+	b := &FullJitterBackoff{Base: 10 * time.Millisecond, Cap: 100 * time.Millisecond}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := b.NextDelay(attempt)
+		if delay < 0 || delay > b.Cap {
+			t.Fatalf("attempt %d: delay %s out of bounds [0, %s]", attempt, delay, b.Cap)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Package reconciler periodically diffs database.DB's open trades against
+// the live MT5 bridge state and repairs the drift that accumulates when
+// the bridge, the database, or the process crashes mid-write: a trade the
+// DB still thinks is open but MT5 has already closed, or an MT5 position
+// with no corresponding DB row at all.
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"trading-system/internal/database"
+	"trading-system/internal/mt5"
+)
+
+// componentReconciler tags every system_logs row this package writes, so
+// reconciliation events can be filtered independently of the signal
+// processor's own audit trail.
+const componentReconciler = "reconciler"
+
+// ClosedDrift records a DB trade that RunOnce found missing from MT5 and
+// (outside dry-run mode) marked closed.
+type ClosedDrift struct {
+	TradeID        int     `json:"trade_id"`
+	MT5Ticket      int64   `json:"mt5_ticket"`
+	SynthesizedPnL float64 `json:"synthesized_pnl"`
+}
+
+// OrphanPosition records an MT5 position with no matching DB trade that
+// RunOnce found and (outside dry-run mode) inserted as a new trade row.
+type OrphanPosition struct {
+	Ticket int64   `json:"ticket"`
+	Symbol string  `json:"symbol"`
+	Volume float64 `json:"volume"`
+}
+
+// Diff reports what one RunOnce pass found (and, outside dry-run mode,
+// repaired).
+type Diff struct {
+	ClosedDrift []ClosedDrift    `json:"closed_drift"`
+	Orphans     []OrphanPosition `json:"orphans"`
+	Refreshed   int              `json:"refreshed"`
+}
+
+// dbClient is the subset of *database.DB RunOnce needs. Narrowing it to an
+// interface (rather than depending on *database.DB directly) lets tests
+// exercise RunOnce's diff/repair logic against an in-memory fake instead
+// of a live Postgres instance - this tree has no testcontainers (or any
+// Postgres test harness) set up anywhere else to extend, so a real DB
+// integration test isn't available here; the mock MT5 bridge below is a
+// real httptest.Server, matching mt5.Client's own test style.
+type dbClient interface {
+	GetOpenTrades(ctx context.Context) ([]*database.Trade, error)
+	UpdateTradeStatus(ctx context.Context, tradeID int, req *database.UpdateTradeStatusRequest) error
+	CreateTrade(ctx context.Context, req *database.CreateTradeRequest) (*database.Trade, error)
+	LogEvent(ctx context.Context, level, message, component string, context json.RawMessage) error
+}
+
+// Reconciler owns one DB/MT5 pairing to reconcile, following the same
+// mt5+db field pairing as execution.Engine.
+type Reconciler struct {
+	db  dbClient
+	mt5 *mt5.Client
+}
+
+// NewReconciler creates a Reconciler.
+func NewReconciler(db *database.DB, mt5Client *mt5.Client) *Reconciler {
+	return &Reconciler{db: db, mt5: mt5Client}
+}
+
+// Start runs RunOnce on every tick of interval until ctx is cancelled,
+// logging (rather than propagating) any pass's error so one failed tick
+// doesn't stop the loop - matching
+// signals.Processor's signalProcessingLoop/positionMonitoringLoop.
+func (r *Reconciler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.RunOnce(ctx, false); err != nil {
+				log.Printf("Error reconciling MT5 state: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce diffs database.DB's open trades against MT5's live positions and
+// orders. When dryRun is true it only returns the Diff, mutating nothing -
+// for an operator to inspect before trusting the automated repair. When
+// dryRun is false it also: marks DB trades missing from MT5 as closed
+// (keeping their last recorded ProfitLoss as the closing P/L; CurrentPrice
+// is not used to recompute it), inserts untracked MT5 positions as
+// new "orphan" trade rows so they become visible to every other trade
+// query, and refreshes current_price/profit_loss/commission/swap on every
+// row that does still match.
+func (r *Reconciler) RunOnce(ctx context.Context, dryRun bool) (*Diff, error) {
+	openTrades, err := r.db.GetOpenTrades(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open trades: %w", err)
+	}
+
+	positions, err := r.mt5.GetPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions from MT5: %w", err)
+	}
+	orders, err := r.mt5.GetOrders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders from MT5: %w", err)
+	}
+
+	positionsByTicket := make(map[int64]*mt5.PositionInfo, len(positions))
+	for _, pos := range positions {
+		positionsByTicket[pos.Ticket] = pos
+	}
+	ordersByTicket := make(map[int64]*mt5.OrderInfo, len(orders))
+	for _, order := range orders {
+		ordersByTicket[order.Ticket] = order
+	}
+
+	diff := &Diff{}
+	knownTickets := make(map[int64]bool, len(openTrades))
+
+	for _, trade := range openTrades {
+		if trade.MT5Ticket == nil || (trade.Status != "filled" && trade.Status != "partial") {
+			continue
+		}
+		knownTickets[*trade.MT5Ticket] = true
+
+		if pos, ok := positionsByTicket[*trade.MT5Ticket]; ok {
+			diff.Refreshed++
+			if !dryRun {
+				if err := r.db.UpdateTradeStatus(ctx, trade.ID, &database.UpdateTradeStatusRequest{
+					Status:       trade.Status,
+					CurrentPrice: &pos.CurrentPrice,
+					ProfitLoss:   &pos.Profit,
+					Commission:   &pos.Commission,
+					Swap:         &pos.Swap,
+				}); err != nil {
+					log.Printf("Reconciler: failed to refresh trade %d: %v", trade.ID, err)
+				}
+			}
+			continue
+		}
+
+		if _, ok := ordersByTicket[*trade.MT5Ticket]; ok {
+			// A filled/partial DB row whose ticket is now a pending order,
+			// not a position, shouldn't happen under normal operation -
+			// leave it alone rather than guessing at its real state.
+			continue
+		}
+
+		// The trade's MT5 ticket is neither an open position nor a pending
+		// order anymore - MT5 considers it closed, but the DB row never
+		// saw that transition (a dropped response, a crash between the
+		// close and the write, etc).
+		diff.ClosedDrift = append(diff.ClosedDrift, ClosedDrift{
+			TradeID:        trade.ID,
+			MT5Ticket:      *trade.MT5Ticket,
+			SynthesizedPnL: trade.ProfitLoss,
+		})
+		if !dryRun {
+			if err := r.db.UpdateTradeStatus(ctx, trade.ID, &database.UpdateTradeStatusRequest{
+				Status:     "closed",
+				ProfitLoss: &trade.ProfitLoss,
+			}); err != nil {
+				log.Printf("Reconciler: failed to close drifted trade %d: %v", trade.ID, err)
+				continue
+			}
+			r.logEvent(ctx, "warn", "reconciliation_closed", map[string]interface{}{
+				"trade_id":   trade.ID,
+				"mt5_ticket": *trade.MT5Ticket,
+			})
+		}
+	}
+
+	for _, pos := range positions {
+		if knownTickets[pos.Ticket] {
+			continue
+		}
+
+		diff.Orphans = append(diff.Orphans, OrphanPosition{Ticket: pos.Ticket, Symbol: pos.Symbol, Volume: pos.Volume})
+		if dryRun {
+			continue
+		}
+
+		orphan, err := r.db.CreateTrade(ctx, &database.CreateTradeRequest{
+			TradeType: "orphan",
+			Symbol:    pos.Symbol,
+			OrderType: "market",
+			Direction: pos.Type,
+			Volume:    pos.Volume,
+		})
+		if err != nil {
+			log.Printf("Reconciler: failed to insert orphan trade for MT5 ticket %d: %v", pos.Ticket, err)
+			continue
+		}
+		if err := r.db.UpdateTradeStatus(ctx, orphan.ID, &database.UpdateTradeStatusRequest{
+			Status:       "filled",
+			MT5Ticket:    &pos.Ticket,
+			CurrentPrice: &pos.CurrentPrice,
+			ProfitLoss:   &pos.Profit,
+			Commission:   &pos.Commission,
+			Swap:         &pos.Swap,
+		}); err != nil {
+			log.Printf("Reconciler: failed to attach MT5 ticket %d to orphan trade %d: %v", pos.Ticket, orphan.ID, err)
+			continue
+		}
+		r.logEvent(ctx, "warn", "reconciliation_orphan_adopted", map[string]interface{}{
+			"trade_id":   orphan.ID,
+			"mt5_ticket": pos.Ticket,
+			"symbol":     pos.Symbol,
+		})
+	}
+
+	return diff, nil
+}
+
+func (r *Reconciler) logEvent(ctx context.Context, level, message string, fields map[string]interface{}) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		data = nil
+	}
+	if err := r.db.LogEvent(ctx, level, message, componentReconciler, data); err != nil {
+		log.Printf("Reconciler: failed to log event %q: %v", message, err)
+	}
+}
@@ -0,0 +1,134 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"trading-system/internal/config"
+	"trading-system/internal/database"
+	"trading-system/internal/mt5"
+)
+
+// fakeDB is an in-memory dbClient fake standing in for Postgres - this
+// tree has no testcontainers (or any DB test harness) anywhere else to
+// extend, so RunOnce's repair logic is exercised against this instead.
+type fakeDB struct {
+	mu       sync.Mutex
+	open     []*database.Trade
+	statuses map[int]*database.UpdateTradeStatusRequest
+	created  []*database.CreateTradeRequest
+	nextID   int
+	events   []string
+}
+
+func (f *fakeDB) GetOpenTrades(ctx context.Context) ([]*database.Trade, error) {
+	return f.open, nil
+}
+
+func (f *fakeDB) UpdateTradeStatus(ctx context.Context, tradeID int, req *database.UpdateTradeStatusRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.statuses == nil {
+		f.statuses = map[int]*database.UpdateTradeStatusRequest{}
+	}
+	f.statuses[tradeID] = req
+	return nil
+}
+
+func (f *fakeDB) CreateTrade(ctx context.Context, req *database.CreateTradeRequest) (*database.Trade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.created = append(f.created, req)
+	return &database.Trade{ID: f.nextID, Symbol: req.Symbol, Direction: req.Direction, Volume: req.Volume}, nil
+}
+
+func (f *fakeDB) LogEvent(ctx context.Context, level, message, component string, ctxData json.RawMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, message)
+	return nil
+}
+
+func testMT5Server(t *testing.T, positions []*mt5.PositionInfo, orders []*mt5.OrderInfo) *mt5.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/positions":
+			json.NewEncoder(w).Encode(positions)
+		case "/orders":
+			json.NewEncoder(w).Encode(orders)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return mt5.NewClient(&config.MT5Config{Endpoint: server.URL, TimeoutSeconds: 5, RetryAttempts: 0})
+}
+
+func TestRunOnceClosesDriftedTrade(t *testing.T) {
+	ticket := int64(555)
+	db := &fakeDB{open: []*database.Trade{
+		{ID: 1, Status: "filled", MT5Ticket: &ticket, ProfitLoss: 12.5},
+	}}
+	client := testMT5Server(t, nil, nil)
+	r := &Reconciler{db: db, mt5: client}
+
+	diff, err := r.RunOnce(context.Background(), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.ClosedDrift) != 1 || diff.ClosedDrift[0].TradeID != 1 {
+		t.Fatalf("expected trade 1 reported as closed drift, got %+v", diff.ClosedDrift)
+	}
+	if got := db.statuses[1].Status; got != "closed" {
+		t.Fatalf("expected trade 1 marked closed, got status %q", got)
+	}
+}
+
+func TestRunOnceAdoptsOrphanPosition(t *testing.T) {
+	db := &fakeDB{}
+	client := testMT5Server(t, []*mt5.PositionInfo{
+		{Ticket: 777, Symbol: "EURUSD", Volume: 0.5, Type: "buy"},
+	}, nil)
+	r := &Reconciler{db: db, mt5: client}
+
+	diff, err := r.RunOnce(context.Background(), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.Orphans) != 1 || diff.Orphans[0].Ticket != 777 {
+		t.Fatalf("expected ticket 777 reported as an orphan, got %+v", diff.Orphans)
+	}
+	if len(db.created) != 1 {
+		t.Fatalf("expected one orphan trade inserted, got %d", len(db.created))
+	}
+}
+
+func TestRunOnceDryRunMutatesNothing(t *testing.T) {
+	ticket := int64(42)
+	db := &fakeDB{open: []*database.Trade{
+		{ID: 1, Status: "filled", MT5Ticket: &ticket},
+	}}
+	client := testMT5Server(t, []*mt5.PositionInfo{
+		{Ticket: 99, Symbol: "EURUSD", Volume: 0.1, Type: "sell"},
+	}, nil)
+	r := &Reconciler{db: db, mt5: client}
+
+	diff, err := r.RunOnce(context.Background(), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.ClosedDrift) != 1 || len(diff.Orphans) != 1 {
+		t.Fatalf("expected dry run to still report drift/orphans, got %+v", diff)
+	}
+	if len(db.statuses) != 0 || len(db.created) != 0 {
+		t.Fatalf("expected dry run to mutate nothing, got statuses=%v created=%v", db.statuses, db.created)
+	}
+}
@@ -0,0 +1,70 @@
+// Package router selects which exchange.ExchangeClient a signal should be
+// routed to, either by an explicit exchange field on the signal or by
+// matching the symbol against per-adapter prefixes.
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	"trading-system/internal/exchange"
+)
+
+// Router holds the set of registered exchange adapters and the symbol
+// prefixes used to pick one automatically.
+type Router struct {
+	adapters map[string]exchange.ExchangeClient
+	prefixes map[string]string // symbol prefix -> adapter name
+}
+
+// New creates an empty Router. Adapters are added via Register.
+func New() *Router {
+	return &Router{
+		adapters: make(map[string]exchange.ExchangeClient),
+		prefixes: make(map[string]string),
+	}
+}
+
+// Register adds an adapter under its own name, optionally associating it
+// with symbol prefixes (e.g. "BYBIT:" or "BTC") used for implicit routing.
+func (r *Router) Register(client exchange.ExchangeClient, prefixes ...string) {
+	r.adapters[client.Name()] = client
+	for _, prefix := range prefixes {
+		r.prefixes[strings.ToUpper(prefix)] = client.Name()
+	}
+}
+
+// List returns the names of all registered adapters.
+func (r *Router) List() []string {
+	names := make([]string, 0, len(r.adapters))
+	for name := range r.adapters {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Get returns the adapter registered under the given name.
+func (r *Router) Get(name string) (exchange.ExchangeClient, error) {
+	client, ok := r.adapters[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", exchange.ErrUnknownExchange, name)
+	}
+	return client, nil
+}
+
+// Resolve picks an adapter for a signal. An explicit exchange name always
+// wins; otherwise the symbol is matched against registered prefixes.
+func (r *Router) Resolve(explicitExchange, symbol string) (exchange.ExchangeClient, error) {
+	if explicitExchange != "" {
+		return r.Get(explicitExchange)
+	}
+
+	upperSymbol := strings.ToUpper(symbol)
+	for prefix, name := range r.prefixes {
+		if strings.HasPrefix(upperSymbol, prefix) {
+			return r.Get(name)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no prefix match for symbol %s", exchange.ErrUnknownExchange, symbol)
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// hmacVector is the on-disk shape of a testdata/vectors/hmac_*.json file.
+// See internal/signals.TestConformanceVectors for the parse/risk vectors.
+type hmacVector struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Input       struct {
+		Secret    string `json:"secret"`
+		Body      string `json:"body"`
+		Signature string `json:"signature"`
+	} `json:"input"`
+	Expected struct {
+		Valid bool `json:"valid"`
+	} `json:"expected"`
+}
+
+// TestHMACConformanceVectors runs the testdata/vectors/hmac_*.json corpus
+// against verifyHMACSignature. Set SKIP_CONFORMANCE=1 to skip.
+func TestHMACConformanceVectors(t *testing.T) {
+	if os.Getenv("SKIP_CONFORMANCE") != "" {
+		t.Skip("SKIP_CONFORMANCE set, skipping conformance vector corpus")
+	}
+
+	paths, err := filepath.Glob("../../testdata/vectors/hmac_*.json")
+	if err != nil {
+		t.Fatalf("failed to list vectors: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no hmac conformance vectors found")
+	}
+
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read vector: %v", err)
+			}
+
+			var vec hmacVector
+			if err := json.Unmarshal(data, &vec); err != nil {
+				t.Fatalf("failed to parse vector: %v", err)
+			}
+
+			got := verifyHMACSignature(vec.Input.Secret, []byte(vec.Input.Body), vec.Input.Signature)
+			if got != vec.Expected.Valid {
+				t.Errorf("%s: verifyHMACSignature() = %v, want %v", vec.Description, got, vec.Expected.Valid)
+			}
+		})
+	}
+}
@@ -1,18 +1,23 @@
 package server
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"trading-system/internal/config"
 	"trading-system/internal/database"
+	"trading-system/internal/logging"
+	"trading-system/internal/mt5"
 	"trading-system/internal/signals"
 )
 
@@ -36,10 +41,16 @@ func New(cfg *config.Config, db *database.DB, processor *signals.Processor) *Ser
 func (s *Server) Router() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", s.handleHealth)
-	mux.HandleFunc("/webhook/tradingview", s.handleTradingViewWebhook)
+	mux.HandleFunc("/webhook/", s.handleWebhook)
 	mux.HandleFunc("/trades", s.handleGetTrades)
 	mux.HandleFunc("/positions", s.handleGetPositions)
-	mux.HandleFunc("/mt5/status", s.handleMT5Status)
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/mt5/status", s.handleStatus) // kept for backwards compatibility
+	mux.HandleFunc("/exchanges", s.handleListExchanges)
+	mux.HandleFunc("/logs", s.handleGetLogs)
+	mux.HandleFunc("/admin/log-level", s.handleSetLogLevel)
+	mux.HandleFunc("/admin/risk-guard/reset", s.handleResetRiskGuard)
+	mux.HandleFunc("/admin/budget-status", s.handleBudgetStatus)
 	return mux
 }
 
@@ -48,100 +59,223 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
 }
 
-func (s *Server) handleTradingViewWebhook(w http.ResponseWriter, r *http.Request) {
+// handleWebhook dispatches /webhook/{source} to the SignalParser registered
+// for that source (see signals.RegisterParser). Per-source HMAC secrets are
+// configured via config.ServerConfig.WebhookSecret.
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	source := strings.TrimPrefix(r.URL.Path, "/webhook/")
+	if source == "" {
+		http.Error(w, "Missing webhook source", http.StatusBadRequest)
+		return
+	}
+
+	// Generate a correlation ID for this request and thread it through
+	// context so every downstream log line (parser, risk check, MT5
+	// submit) and the audit SystemLog rows below can be joined by it.
+	ctx, correlationID := logging.WithCorrelationID(r.Context())
+	w.Header().Set("X-Correlation-ID", correlationID)
+	logger := logging.FromContext(ctx)
+
 	// Read request body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		log.Printf("Error reading webhook body: %v", err)
+		logger.Error("failed to read webhook body", "source", source, "error", err)
 		http.Error(w, "Bad request", http.StatusBadRequest)
 		return
 	}
 	defer r.Body.Close()
 
-	// Verify webhook signature if secret is configured
-	// if s.config.Server.WebhookSecret != "" {
-	// 	if !s.verifyWebhookSignature(r, body) {
-	// 		log.Printf("Invalid webhook signature")
-	// 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-	// 		return
-	// 	}
-	// }
+	// Verify webhook signature; mandatory whenever a secret is configured
+	// for this source.
+	if s.config.Server.SecretFor(source) != "" {
+		if !s.verifyWebhookSignature(source, r, body) {
+			logger.Warn("invalid webhook signature", "source", source)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	logger.Info("webhook received", "source", source)
+	s.auditLog(ctx, "info", "webhook received", "server", map[string]interface{}{"source": source})
 
 	// Process the webhook
-	signal, err := s.signalProcessor.ProcessWebhook(r.Context(), body, "tradingview")
+	signal, err := s.signalProcessor.ProcessWebhook(ctx, r.Header, body, source)
 	if err != nil {
-		log.Printf("Error processing webhook: %v", err)
+		logger.Error("failed to process webhook", "source", source, "error", err)
+		s.auditLog(ctx, "error", "failed to process webhook", "server", map[string]interface{}{"source": source, "error": err.Error()})
+
+		var budgetErr *signals.BudgetExceededError
+		if errors.As(err, &budgetErr) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status":         "rejected",
+				"reason":         budgetErr.Reason,
+				"correlation_id": correlationID,
+			})
+			return
+		}
+
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("Successfully processed webhook, created signal %d", signal.ID)
+	logger.Info("webhook processed", "source", source, "signal_id", signal.ID)
+	s.auditLog(ctx, "info", "webhook processed", "server", map[string]interface{}{"source": source, "signal_id": signal.ID})
 
 	// Return success response
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":    "success",
-		"signal_id": signal.ID,
-		"message":   "Signal processed successfully",
+		"status":         "success",
+		"signal_id":      signal.ID,
+		"correlation_id": correlationID,
+		"message":        "Signal processed successfully",
 	})
 }
 
-// verifyWebhookSignature verifies the webhook signature for security
-func (s *Server) verifyWebhookSignature(r *http.Request, body []byte) bool {
-	signature := r.Header.Get("X-Signature")
+// verifyWebhookSignature verifies the webhook signature for a given source
+// using that source's configured secret and header name.
+func (s *Server) verifyWebhookSignature(source string, r *http.Request, body []byte) bool {
+	signature := r.Header.Get(s.config.Server.HeaderNameFor(source))
 	if signature == "" {
 		signature = r.Header.Get("X-Hub-Signature-256")
 	}
 
+	return verifyHMACSignature(s.config.Server.SecretFor(source), body, signature)
+}
+
+// verifyHMACSignature checks body against signature (optionally prefixed
+// with "sha256=", as GitHub-style webhooks do) using an HMAC-SHA256 of
+// secret. Extracted as a pure function so it can be exercised by the
+// conformance test corpus without standing up a Server.
+func verifyHMACSignature(secret string, body []byte, signature string) bool {
 	if signature == "" {
 		return false
 	}
 
-	// Remove "sha256=" prefix if present
 	signature = strings.TrimPrefix(signature, "sha256=")
 
-	// Calculate expected signature
-	mac := hmac.New(sha256.New, []byte(s.config.Server.WebhookSecret))
+	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write(body)
 	expectedSignature := hex.EncodeToString(mac.Sum(nil))
 
 	return hmac.Equal([]byte(signature), []byte(expectedSignature))
 }
 
+// handleGetTrades returns open trades by default. With any of the closed-
+// trade query params (?since=, ?cursor=, ?symbol=, ?source=, ?status=,
+// ?direction=, ?limit=) it instead pages through closed trades via
+// QueryClosedTrades, returning {"trades": [...], "next_cursor": "..."}.
 func (s *Server) handleGetTrades(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	trades, err := s.db.GetOpenTrades(r.Context())
+	q := r.URL.Query()
+	if len(q) == 0 {
+		trades, err := s.db.GetOpenTrades(r.Context())
+		if err != nil {
+			log.Printf("Error getting trades: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(trades)
+		return
+	}
+
+	filter := database.TradeFilter{
+		Symbol:    q.Get("symbol"),
+		Source:    q.Get("source"),
+		Direction: q.Get("direction"),
+	}
+
+	if status := q.Get("status"); status != "" {
+		filter.Status = strings.Split(status, ",")
+	}
+
+	if since := q.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			http.Error(w, "invalid since: must be RFC3339", http.StatusBadRequest)
+			return
+		}
+		filter.Since = parsed
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		until, lastID, err := database.ParseCursor(database.Cursor(cursor))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.Until = until
+		filter.LastTradeID = lastID
+	}
+
+	if limit := q.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "invalid limit: must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	trades, nextCursor, err := s.db.QueryClosedTrades(r.Context(), filter)
 	if err != nil {
-		log.Printf("Error getting trades: %v", err)
+		log.Printf("Error querying closed trades: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(trades)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"trades":      trades,
+		"next_cursor": nextCursor,
+	})
 }
 
+// handleGetPositions returns open positions. With no ?exchange= query
+// parameter it falls back to the MT5 bridge for backwards compatibility;
+// otherwise it routes to the named exchange adapter.
 func (s *Server) handleGetPositions(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Get positions from MT5 via signal processor
-	mt5Client := s.signalProcessor.GetMT5Client()
-	positions, err := mt5Client.GetPositions(r.Context())
+	exchangeName := r.URL.Query().Get("exchange")
+	if exchangeName == "" {
+		mt5Client := s.signalProcessor.GetMT5Client()
+		positions, err := mt5Client.GetPositions(r.Context())
+		if err != nil {
+			log.Printf("Error getting MT5 positions: %v", err)
+			http.Error(w, "Failed to get positions", bridgeStatusCode(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(positions)
+		return
+	}
+
+	adapter, err := s.signalProcessor.GetRouter().Get(exchangeName)
 	if err != nil {
-		log.Printf("Error getting MT5 positions: %v", err)
-		http.Error(w, "Failed to get positions", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	positions, err := adapter.GetPositions(r.Context())
+	if err != nil {
+		log.Printf("Error getting %s positions: %v", exchangeName, err)
+		http.Error(w, "Failed to get positions", bridgeStatusCode(err))
 		return
 	}
 
@@ -149,22 +283,50 @@ func (s *Server) handleGetPositions(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(positions)
 }
 
-func (s *Server) handleMT5Status(w http.ResponseWriter, r *http.Request) {
+// handleStatus reports connectivity and account info. With no ?exchange=
+// query parameter it reports MT5 status; otherwise it reports the named
+// exchange adapter's status.
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	mt5Client := s.signalProcessor.GetMT5Client()
+	exchangeName := r.URL.Query().Get("exchange")
+	if exchangeName == "" {
+		mt5Client := s.signalProcessor.GetMT5Client()
+
+		status := map[string]interface{}{
+			"connected": mt5Client.IsConnected(r.Context()),
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		}
+
+		if status["connected"].(bool) {
+			accountInfo, err := mt5Client.GetAccountInfo(r.Context())
+			if err == nil {
+				status["account"] = accountInfo
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+		return
+	}
+
+	adapter, err := s.signalProcessor.GetRouter().Get(exchangeName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 
 	status := map[string]interface{}{
-		"connected": mt5Client.IsConnected(r.Context()),
+		"exchange":  exchangeName,
+		"connected": adapter.IsConnected(r.Context()),
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	}
 
-	// Get account info if connected
 	if status["connected"].(bool) {
-		accountInfo, err := mt5Client.GetAccountInfo(r.Context())
+		accountInfo, err := adapter.GetAccountInfo(r.Context())
 		if err == nil {
 			status["account"] = accountInfo
 		}
@@ -173,3 +335,149 @@ func (s *Server) handleMT5Status(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(status)
 }
+
+// bridgeStatusCode maps an execution-venue error to the HTTP status that
+// best reflects it: 504 when the request ran out of time, 503 when the
+// bridge/adapter could not be reached at all, 500 otherwise.
+func bridgeStatusCode(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, mt5.ErrMT5Disconnected):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// auditLog persists an audit trail entry to SystemLog, stamped with ctx's
+// correlation ID (if any), so GET /logs can reconstruct one alert's path
+// from ingress through MT5 submission without grepping log files. Failures
+// are logged but not returned - an audit write should never fail the
+// request it's describing.
+func (s *Server) auditLog(ctx context.Context, level, message, component string, fields map[string]interface{}) {
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	if id := logging.CorrelationID(ctx); id != "" {
+		fields["correlation_id"] = id
+	}
+
+	contextData, err := json.Marshal(fields)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to marshal audit log context", "error", err)
+		return
+	}
+
+	if err := s.db.LogEvent(ctx, level, message, component, contextData); err != nil {
+		logging.FromContext(ctx).Error("failed to write audit log", "error", err)
+	}
+}
+
+// handleGetLogs lets operators trace a single request by correlation ID
+// (and/or filter by level / since) across every component that called
+// auditLog, e.g. GET /logs?correlation_id=...&level=error&since=...
+func (s *Server) handleGetLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter := database.SystemLogFilter{
+		CorrelationID: r.URL.Query().Get("correlation_id"),
+		Level:         r.URL.Query().Get("level"),
+	}
+
+	if since := r.URL.Query().Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			http.Error(w, "invalid since: must be RFC3339", http.StatusBadRequest)
+			return
+		}
+		filter.Since = parsed
+	}
+
+	entries, err := s.db.GetSystemLogs(r.Context(), filter)
+	if err != nil {
+		log.Printf("Error getting system logs: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// handleSetLogLevel hot-reloads the process-wide log level, e.g.
+// POST /admin/log-level {"level": "debug"}.
+func (s *Server) handleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if err := logging.SetLevel(req.Level); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok", "level": req.Level})
+}
+
+// handleResetRiskGuard clears a tripped RiskGuard kill-switch, e.g.
+// POST /admin/risk-guard/reset. Today's realized P/L and peak equity are
+// left intact - only the trip flag and losing streak are cleared.
+func (s *Server) handleResetRiskGuard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.signalProcessor.ResetRiskGuard(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// handleBudgetStatus reports today's accumulated volume/fees against the
+// configured daily budget, e.g. GET /admin/budget-status.
+func (s *Server) handleBudgetStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status, err := s.signalProcessor.GetBudgetStatus(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// handleListExchanges lists the names of all registered exchange adapters.
+func (s *Server) handleListExchanges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"exchanges": s.signalProcessor.GetRouter().List(),
+	})
+}
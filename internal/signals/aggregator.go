@@ -0,0 +1,151 @@
+package signals
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"trading-system/internal/config"
+)
+
+// vote records one source's signal for a (symbol, direction) pair within
+// the aggregator's sliding window.
+type vote struct {
+	source string
+	weight float64
+	at     time.Time
+}
+
+// Consensus describes the weighted vote state for a (symbol, direction)
+// pair after a signal is recorded. It is persisted into the Signal.Payload
+// so the final decision has full provenance for later analysis.
+type Consensus struct {
+	Score     float64      `json:"score"`
+	Threshold float64      `json:"threshold"`
+	Reached   bool         `json:"reached"`
+	Sources   []SourceVote `json:"sources"`
+}
+
+// SourceVote records one source's contribution to a Consensus.
+type SourceVote struct {
+	Source string  `json:"source"`
+	Weight float64 `json:"weight"`
+}
+
+// Aggregator buffers signals from multiple sources (TradingView, custom
+// HTTP, MT5 indicators, ...) keyed by (symbol, direction) and computes a
+// weighted consensus score. A trade is only created once enough sources
+// agree within a sliding window, letting users layer several independent
+// signal strategies - trend-following, mean-reversion, Ichimoku-style
+// confluence - instead of trading on every raw alert.
+type Aggregator struct {
+	cfg    config.AggregatorConfig
+	window time.Duration
+
+	mu    sync.Mutex
+	votes map[string][]vote // keyed by symbol+"|"+direction
+
+	// consumed records, per key, the sorted source set that last earned a
+	// "reached" transition - so Record only reports a trigger once per
+	// distinct majority, not on every subsequent vote while it stays
+	// reached. It's cleared whenever the vote set falls back out of
+	// consensus (ages out of the window), letting a later majority - even
+	// one formed from the same sources re-voting - trigger again.
+	consumed map[string]string
+}
+
+// NewAggregator creates an Aggregator from its config.
+func NewAggregator(cfg config.AggregatorConfig) *Aggregator {
+	return &Aggregator{
+		cfg:      cfg,
+		window:   time.Duration(cfg.WindowSeconds) * time.Second,
+		votes:    make(map[string][]vote),
+		consumed: make(map[string]string),
+	}
+}
+
+// Record appends a vote for (symbol, direction) from source at time at,
+// evicts votes that have aged out of the window, and returns the
+// resulting Consensus along with whether this call is the transition into
+// "reached" - i.e. whether the caller should act on it now. A source that
+// votes more than once within the window only counts once, at its
+// configured weight; re-voting by a source already in the majority while
+// it is still in effect reports Consensus.Reached but triggered = false,
+// so callers only open one trade per distinct majority. A vote that
+// changes the set of contributing sources (a new source joining, or one
+// aging out while the rest still reach the threshold) counts as a new
+// majority and triggers again.
+func (a *Aggregator) Record(symbol, direction, source string, at time.Time) (Consensus, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := symbol + "|" + direction
+	votes := append(a.votes[key], vote{source: source, weight: a.weightFor(source), at: at})
+
+	cutoff := at.Add(-a.window)
+	fresh := votes[:0]
+	for _, v := range votes {
+		if v.at.After(cutoff) {
+			fresh = append(fresh, v)
+		}
+	}
+	a.votes[key] = fresh
+
+	consensus := a.score(fresh)
+
+	if !consensus.Reached {
+		delete(a.consumed, key)
+		return consensus, false
+	}
+
+	setKey := sourceSetKey(consensus.Sources)
+	if a.consumed[key] == setKey {
+		return consensus, false
+	}
+	a.consumed[key] = setKey
+
+	return consensus, true
+}
+
+// sourceSetKey returns a stable, order-independent fingerprint of the
+// sources contributing to a Consensus, used to tell a freshly-formed
+// majority apart from one already acted on.
+func sourceSetKey(sources []SourceVote) string {
+	names := make([]string, len(sources))
+	for i, sv := range sources {
+		names[i] = sv.Source
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// weightFor returns source's configured voting weight, defaulting to 1.0
+// for sources with no explicit entry.
+func (a *Aggregator) weightFor(source string) float64 {
+	if w, ok := a.cfg.SourceWeights[source]; ok {
+		return w
+	}
+	return 1.0
+}
+
+func (a *Aggregator) score(votes []vote) Consensus {
+	bySource := make(map[string]float64, len(votes))
+	for _, v := range votes {
+		bySource[v.source] = v.weight
+	}
+
+	sources := make([]SourceVote, 0, len(bySource))
+	var total float64
+	for source, weight := range bySource {
+		sources = append(sources, SourceVote{Source: source, Weight: weight})
+		total += weight
+	}
+
+	return Consensus{
+		Score:     total,
+		Threshold: a.cfg.VoteThreshold,
+		Reached:   total >= a.cfg.VoteThreshold,
+		Sources:   sources,
+	}
+}
@@ -0,0 +1,182 @@
+package signals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading-system/internal/config"
+)
+
+// conformanceVector is the on-disk shape of a file under
+// testdata/vectors/. "parse" vectors exercise parseTradingViewWebhookData;
+// "risk" vectors exercise EvaluateRiskDecision. Both run without touching
+// the network or a database.
+type conformanceVector struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Input       struct {
+		Body            string            `json:"body"`
+		Headers         map[string]string `json:"headers"`
+		MaxAgeSeconds   int               `json:"max_age_seconds"`
+		MaxPositionSize float64           `json:"max_position_size"`
+		MaxOpenPositions int              `json:"max_open_positions"`
+		Volume          float64           `json:"volume"`
+		OpenPositions   int               `json:"open_positions"`
+	} `json:"input"`
+	Expected struct {
+		ParseError    bool   `json:"parse_error"`
+		RiskError     bool   `json:"risk_error"`
+		ErrorContains string `json:"error_contains"`
+		Signal        struct {
+			Source     string   `json:"source"`
+			Symbol     string   `json:"symbol"`
+			SignalType string   `json:"signal_type"`
+			Price      *float64 `json:"price"`
+			StopLoss   *float64 `json:"stop_loss"`
+			TP1        *float64 `json:"tp1"`
+			TP2        *float64 `json:"tp2"`
+			// PriceExact, when set, asserts req.Price's exact decimal
+			// string instead of going through Price's *float64 round
+			// trip - for vectors whose whole point is proving a value
+			// that float64 can't represent exactly survives parsing.
+			PriceExact string `json:"price_exact,omitempty"`
+		} `json:"signal"`
+	} `json:"expected"`
+}
+
+// TestConformanceVectors runs every testdata/vectors/*.json file against
+// the parser and risk-decision logic. Set SKIP_CONFORMANCE=1 to skip (e.g.
+// when iterating on an unrelated change and the corpus hasn't caught up).
+func TestConformanceVectors(t *testing.T) {
+	if os.Getenv("SKIP_CONFORMANCE") != "" {
+		t.Skip("SKIP_CONFORMANCE set, skipping conformance vector corpus")
+	}
+
+	paths, err := filepath.Glob("../../testdata/vectors/*.json")
+	if err != nil {
+		t.Fatalf("failed to list vectors: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no conformance vectors found")
+	}
+
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read vector: %v", err)
+			}
+
+			var vec conformanceVector
+			if err := json.Unmarshal(data, &vec); err != nil {
+				t.Fatalf("failed to parse vector: %v", err)
+			}
+
+			switch vec.Type {
+			case "risk":
+				runRiskVector(t, &vec)
+			case "parse":
+				runParseVector(t, &vec)
+			default:
+				t.Skipf("vector type %q is not handled by this package (see internal/server for hmac vectors)", vec.Type)
+			}
+		})
+	}
+}
+
+func runParseVector(t *testing.T, vec *conformanceVector) {
+	maxAge := time.Duration(vec.Input.MaxAgeSeconds) * time.Second
+	req, err := parseTradingViewWebhookData([]byte(vec.Input.Body), maxAge)
+
+	if vec.Expected.ParseError {
+		if err == nil {
+			t.Fatalf("%s: expected parse error, got none", vec.Description)
+		}
+		if vec.Expected.ErrorContains != "" && !strings.Contains(err.Error(), vec.Expected.ErrorContains) {
+			t.Fatalf("%s: error %q does not contain %q", vec.Description, err.Error(), vec.Expected.ErrorContains)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("%s: unexpected parse error: %v", vec.Description, err)
+	}
+
+	want := vec.Expected.Signal
+	if want.Source != "" && req.Source != want.Source {
+		t.Errorf("%s: source = %q, want %q", vec.Description, req.Source, want.Source)
+	}
+	if req.Symbol != want.Symbol {
+		t.Errorf("%s: symbol = %q, want %q", vec.Description, req.Symbol, want.Symbol)
+	}
+	if req.SignalType != want.SignalType {
+		t.Errorf("%s: signal_type = %q, want %q", vec.Description, req.SignalType, want.SignalType)
+	}
+	assertFloatPtrEqual(t, vec.Description, "price", nullDecimalFloatPtr(req.Price), want.Price)
+	assertFloatPtrEqual(t, vec.Description, "stop_loss", nullDecimalFloatPtr(req.StopLoss), want.StopLoss)
+	assertFloatPtrEqual(t, vec.Description, "tp1", nullDecimalFloatPtr(req.TP1), want.TP1)
+	assertFloatPtrEqual(t, vec.Description, "tp2", nullDecimalFloatPtr(req.TP2), want.TP2)
+	if want.PriceExact != "" {
+		if !req.Price.Valid {
+			t.Fatalf("%s: price_exact: req.Price is not set, want %s", vec.Description, want.PriceExact)
+		}
+		if got := req.Price.Decimal.String(); got != want.PriceExact {
+			t.Errorf("%s: price_exact: price = %s, want %s", vec.Description, got, want.PriceExact)
+		}
+	}
+}
+
+// nullDecimalFloatPtr mirrors decimalString's handling of
+// decimal.NullDecimal (see idempotencyHash in processor.go), adapted to
+// *float64 so this file's vectors can keep comparing against plain floats.
+func nullDecimalFloatPtr(d decimal.NullDecimal) *float64 {
+	if !d.Valid {
+		return nil
+	}
+	f, _ := d.Decimal.Float64()
+	return &f
+}
+
+func runRiskVector(t *testing.T, vec *conformanceVector) {
+	risk := config.RiskConfig{
+		MaxPositionSize:  vec.Input.MaxPositionSize,
+		MaxOpenPositions: vec.Input.MaxOpenPositions,
+	}
+
+	err := EvaluateRiskDecision(risk, vec.Input.Volume, vec.Input.OpenPositions)
+
+	if vec.Expected.RiskError {
+		if err == nil {
+			t.Fatalf("%s: expected risk error, got none", vec.Description)
+		}
+		if vec.Expected.ErrorContains != "" && !strings.Contains(err.Error(), vec.Expected.ErrorContains) {
+			t.Fatalf("%s: error %q does not contain %q", vec.Description, err.Error(), vec.Expected.ErrorContains)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("%s: unexpected risk error: %v", vec.Description, err)
+	}
+}
+
+func assertFloatPtrEqual(t *testing.T, desc, field string, got, want *float64) {
+	t.Helper()
+	if want == nil {
+		return
+	}
+	if got == nil {
+		t.Errorf("%s: %s = nil, want %v", desc, field, *want)
+		return
+	}
+	if *got != *want {
+		t.Errorf("%s: %s = %v, want %v", desc, field, *got, *want)
+	}
+}
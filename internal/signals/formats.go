@@ -0,0 +1,188 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"trading-system/internal/database"
+)
+
+// FormatDecoder decodes a webhook body into a CreateSignalRequest. Matches
+// reports whether this decoder applies to the given headers/body, so
+// FormatRegistry can pick the right one without TradingViewParser.Parse
+// branching on Content-Type or body shape itself. This is the groundwork
+// for supporting additional signal sources by registering a new decoder
+// rather than adding another if/else to Parse.
+type FormatDecoder interface {
+	Matches(headers http.Header, body []byte) bool
+	Decode(body []byte) (*database.CreateSignalRequest, error)
+}
+
+var (
+	formatRegistryMu sync.RWMutex
+	formatRegistry   = map[string]FormatDecoder{}
+	formatOrder      []string
+)
+
+// RegisterFormat registers a FormatDecoder under name, overwriting any
+// existing registration with that name. Decoders are tried in
+// registration order by formatFor, so register more specific formats
+// (e.g. a Content-Type-gated binary format) before general-purpose
+// fallbacks. Call from an init() func or at startup to add proprietary
+// formats without forking.
+func RegisterFormat(name string, decoder FormatDecoder) {
+	formatRegistryMu.Lock()
+	defer formatRegistryMu.Unlock()
+	if _, exists := formatRegistry[name]; !exists {
+		formatOrder = append(formatOrder, name)
+	}
+	formatRegistry[name] = decoder
+}
+
+// formatFor returns the first registered FormatDecoder (in registration
+// order) whose Matches reports true for headers/body.
+func formatFor(headers http.Header, body []byte) (FormatDecoder, bool) {
+	formatRegistryMu.RLock()
+	defer formatRegistryMu.RUnlock()
+	for _, name := range formatOrder {
+		if d := formatRegistry[name]; d.Matches(headers, body) {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+func init() {
+	RegisterFormat("mql_binary", &mqlBinaryFormat{})
+	RegisterFormat("tradingview_json", &tradingViewJSONFormat{})
+	RegisterFormat("simple_pipe", &simplePipeFormat{})
+}
+
+// mqlBinaryFormat matches the compact binary layout (see
+// parseCompactBinarySignal) by its dedicated Content-Type, since the
+// payload itself carries no self-describing structure to sniff.
+type mqlBinaryFormat struct{}
+
+func (f *mqlBinaryFormat) Matches(headers http.Header, body []byte) bool {
+	return headers.Get("Content-Type") == compactBinaryContentType
+}
+
+func (f *mqlBinaryFormat) Decode(body []byte) (*database.CreateSignalRequest, error) {
+	// maxAge is 0 here - the replay-window check now happens once,
+	// centrally, in TradingViewParser.Parse against the decoded
+	// CreateSignalRequest.Timestamp, rather than duplicated per decoder.
+	return parseCompactBinarySignal(body, 0)
+}
+
+// tradingViewJSONFormat matches the JSON TradingView alert body.
+type tradingViewJSONFormat struct{}
+
+func (f *tradingViewJSONFormat) Matches(headers http.Header, body []byte) bool {
+	return json.Valid(body)
+}
+
+func (f *tradingViewJSONFormat) Decode(body []byte) (*database.CreateSignalRequest, error) {
+	return parseTradingViewWebhookData(body, 0)
+}
+
+// simplePipeFormat matches the pipe-delimited fallback format:
+// ticker|action|entry|stop_loss|tp1|tp2|volume|timestamp
+type simplePipeFormat struct{}
+
+func (f *simplePipeFormat) Matches(headers http.Header, body []byte) bool {
+	return !json.Valid(body) && bytes.Contains(body, []byte("|"))
+}
+
+func (f *simplePipeFormat) Decode(body []byte) (*database.CreateSignalRequest, error) {
+	return parseSimpleFormatData(string(body))
+}
+
+// timestampDecoder decodes a raw timestamp value (already unwrapped from
+// its JSON string/number shape) into an RFC3339 string, reporting ok=false
+// if raw isn't in the format it handles.
+type timestampDecoder struct {
+	name   string
+	decode func(raw string) (string, bool)
+}
+
+// timestampDecoders is tried in order for every raw timestamp value,
+// mirroring the magnitude-dispatch pattern used for duration unit
+// disambiguation elsewhere: numbers are classified by how many digits
+// they have before being converted to a time, largest magnitude first so
+// a nanosecond value isn't misread as a far-future Unix second count.
+var timestampDecoders = []timestampDecoder{
+	{name: "rfc3339", decode: decodeRFC3339Timestamp},
+	{name: "unix_nanos", decode: decodeUnixNanosTimestamp},
+	{name: "unix_millis", decode: decodeUnixMillisTimestamp},
+	{name: "unix_seconds", decode: decodeUnixSecondsTimestamp},
+	{name: "go_duration", decode: decodeGoDurationTimestamp},
+}
+
+// decodeTimestamp tries every registered timestampDecoder in order,
+// returning the first successful RFC3339 decode.
+func decodeTimestamp(raw string) (string, bool) {
+	for _, d := range timestampDecoders {
+		if decoded, ok := d.decode(raw); ok {
+			return decoded, true
+		}
+	}
+	return "", false
+}
+
+// maxValidUnixSeconds bounds a decoded timestamp to the year 1970-2100,
+// matching the range check parseTimestampValue has always applied.
+const maxValidUnixSeconds = 4102444800 // Jan 1, 2100
+
+func decodeRFC3339Timestamp(raw string) (string, bool) {
+	if _, err := time.Parse(time.RFC3339, raw); err != nil {
+		return "", false
+	}
+	return raw, true
+}
+
+func decodeUnixSecondsTimestamp(raw string) (string, bool) {
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || n <= 0 || n > maxValidUnixSeconds {
+		return "", false
+	}
+	return time.Unix(n, 0).Format(time.RFC3339), true
+}
+
+func decodeUnixMillisTimestamp(raw string) (string, bool) {
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || n <= 1e12 {
+		return "", false
+	}
+	seconds := n / 1000
+	if seconds > maxValidUnixSeconds {
+		return "", false
+	}
+	return time.Unix(seconds, 0).Format(time.RFC3339), true
+}
+
+func decodeUnixNanosTimestamp(raw string) (string, bool) {
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || n <= 1e17 {
+		return "", false
+	}
+	seconds := n / 1e9
+	if seconds > maxValidUnixSeconds {
+		return "", false
+	}
+	return time.Unix(seconds, 0).Format(time.RFC3339), true
+}
+
+// decodeGoDurationTimestamp interprets raw as a Go duration offset from
+// now (e.g. "-5m" for "5 minutes ago"), for sources that report signal
+// age rather than an absolute timestamp.
+func decodeGoDurationTimestamp(raw string) (string, bool) {
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return "", false
+	}
+	return time.Now().Add(d).Format(time.RFC3339), true
+}
@@ -0,0 +1,373 @@
+package signals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+
+	"trading-system/internal/database"
+)
+
+// SignalParser normalizes a webhook body (and its headers) from a given
+// source into a CreateSignalRequest. Implementations are registered by
+// name via RegisterParser and looked up by the /webhook/{source} route.
+type SignalParser interface {
+	Parse(ctx context.Context, headers http.Header, body []byte) (*database.CreateSignalRequest, error)
+}
+
+var (
+	parserRegistryMu sync.RWMutex
+	parserRegistry   = map[string]SignalParser{}
+)
+
+// RegisterParser registers a SignalParser under name, overwriting any
+// existing registration. Call from an init() func or at startup to add
+// proprietary group/source formats without forking.
+func RegisterParser(name string, parser SignalParser) {
+	parserRegistryMu.Lock()
+	defer parserRegistryMu.Unlock()
+	parserRegistry[strings.ToLower(name)] = parser
+}
+
+// parserFor looks up the registered parser for a source name.
+func parserFor(name string) (SignalParser, bool) {
+	parserRegistryMu.RLock()
+	defer parserRegistryMu.RUnlock()
+	parser, ok := parserRegistry[strings.ToLower(name)]
+	return parser, ok
+}
+
+func init() {
+	RegisterParser("tradingview", &TradingViewParser{})
+	RegisterParser("discord", &DiscordParser{})
+	RegisterParser("telegram", &TelegramParser{})
+	RegisterParser("fixlite", &FixLiteParser{})
+	RegisterParser("mt5csv", &MT5CSVParser{})
+}
+
+// TradingViewParser handles the existing JSON / pipe-delimited TradingView
+// alert formats.
+type TradingViewParser struct {
+	// MaxAge rejects signals whose embedded timestamp is older than this,
+	// mitigating replay of a captured webhook payload. Zero disables the
+	// check.
+	MaxAge time.Duration
+}
+
+// Parse implements SignalParser for TradingView alerts. The body format
+// (JSON, pipe-delimited, or compact binary) is resolved against the
+// package-wide FormatRegistry rather than branched on here, so a new
+// format only needs a RegisterFormat call, not a change to Parse.
+func (t *TradingViewParser) Parse(ctx context.Context, headers http.Header, body []byte) (*database.CreateSignalRequest, error) {
+	decoder, ok := formatFor(headers, body)
+	if !ok {
+		return nil, fmt.Errorf("no registered format decoder matches this webhook")
+	}
+
+	req, err := decoder.Decode(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.MaxAge > 0 && req.Timestamp != "" {
+		if parsed, err := time.Parse(time.RFC3339, req.Timestamp); err == nil {
+			if age := time.Since(parsed); age > t.MaxAge {
+				return nil, fmt.Errorf("signal timestamp %s is older than max age %s (age %s), rejecting as a possible replay", req.Timestamp, t.MaxAge, age)
+			}
+		}
+	}
+
+	return req, nil
+}
+
+// JSONFieldMapping describes how to pull a normalized signal out of an
+// arbitrary JSON payload. It is typically loaded from a YAML config file,
+// e.g.:
+//
+//	ticker_field: "symbol"
+//	action_field: "side"
+//	price_field: "entry_price"
+type JSONFieldMapping struct {
+	TickerField     string `yaml:"ticker_field"`
+	ActionField     string `yaml:"action_field"`
+	PriceField      string `yaml:"price_field"`
+	StopLossField   string `yaml:"stop_loss_field"`
+	TakeProfitField string `yaml:"take_profit_field"`
+}
+
+// GenericJSONParser maps an arbitrary JSON webhook body onto a
+// CreateSignalRequest using a configurable field mapping.
+type GenericJSONParser struct {
+	Source  string
+	Mapping JSONFieldMapping
+}
+
+// NewGenericJSONParser builds a GenericJSONParser from a YAML mapping
+// document, e.g. loaded from a per-source config file on startup.
+func NewGenericJSONParser(source string, mappingYAML []byte) (*GenericJSONParser, error) {
+	var mapping JSONFieldMapping
+	if err := yaml.Unmarshal(mappingYAML, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse field mapping for %s: %w", source, err)
+	}
+	if mapping.TickerField == "" || mapping.ActionField == "" {
+		return nil, fmt.Errorf("field mapping for %s must set ticker_field and action_field", source)
+	}
+	return &GenericJSONParser{Source: source, Mapping: mapping}, nil
+}
+
+// Parse implements SignalParser using the configured field mapping.
+func (g *GenericJSONParser) Parse(ctx context.Context, headers http.Header, body []byte) (*database.CreateSignalRequest, error) {
+	raw, err := unmarshalJSONMap(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s payload: %w", g.Source, err)
+	}
+
+	ticker, _ := raw[g.Mapping.TickerField].(string)
+	action, _ := raw[g.Mapping.ActionField].(string)
+	if ticker == "" {
+		return nil, fmt.Errorf("%s: missing %s field", g.Source, g.Mapping.TickerField)
+	}
+	if action == "" {
+		return nil, fmt.Errorf("%s: missing %s field", g.Source, g.Mapping.ActionField)
+	}
+
+	req := &database.CreateSignalRequest{
+		Source:     g.Source,
+		Symbol:     ticker,
+		SignalType: strings.ToLower(action),
+		Payload:    body,
+	}
+
+	if g.Mapping.PriceField != "" {
+		req.Price = floatFieldFromMap(raw, g.Mapping.PriceField)
+	}
+	if g.Mapping.StopLossField != "" {
+		req.StopLoss = floatFieldFromMap(raw, g.Mapping.StopLossField)
+	}
+	if g.Mapping.TakeProfitField != "" {
+		req.TakeProfit = floatFieldFromMap(raw, g.Mapping.TakeProfitField)
+	}
+
+	return req, nil
+}
+
+// discordAlertPattern matches relay messages like "BUY XAUUSD @ 2345 SL 2340 TP 2360".
+var discordAlertPattern = regexp.MustCompile(`(?i)(BUY|SELL)\s+([A-Z0-9]+)\s*@\s*([\d.]+)(?:\s+SL\s+([\d.]+))?(?:\s+TP\s+([\d.]+))?`)
+
+// DiscordParser parses alert text embedded in Discord webhook payloads
+// (the `content` field of an embed message).
+type DiscordParser struct{}
+
+// Parse implements SignalParser for Discord relay messages.
+func (d *DiscordParser) Parse(ctx context.Context, headers http.Header, body []byte) (*database.CreateSignalRequest, error) {
+	raw, err := unmarshalJSONMap(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse discord payload: %w", err)
+	}
+
+	content, _ := raw["content"].(string)
+	if content == "" {
+		return nil, fmt.Errorf("discord payload missing content field")
+	}
+
+	return parseAlertText("discord", content, body)
+}
+
+// TelegramParser parses alert text forwarded from a Telegram bot webhook,
+// using the same "BUY SYMBOL @ price SL x TP y" convention as Discord.
+type TelegramParser struct{}
+
+// Parse implements SignalParser for Telegram forwarded messages.
+func (t *TelegramParser) Parse(ctx context.Context, headers http.Header, body []byte) (*database.CreateSignalRequest, error) {
+	raw, err := unmarshalJSONMap(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse telegram payload: %w", err)
+	}
+
+	message, ok := raw["message"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("telegram payload missing message field")
+	}
+	text, _ := message["text"].(string)
+	if text == "" {
+		return nil, fmt.Errorf("telegram message missing text field")
+	}
+
+	return parseAlertText("telegram", text, body)
+}
+
+// parseAlertText extracts a normalized signal from free-form alert text
+// shared by the Discord and Telegram parsers.
+func parseAlertText(source, text string, originalPayload []byte) (*database.CreateSignalRequest, error) {
+	match := discordAlertPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil, fmt.Errorf("%s: could not parse alert text: %q", source, text)
+	}
+
+	req := &database.CreateSignalRequest{
+		Source:     source,
+		Symbol:     strings.ToUpper(match[2]),
+		SignalType: strings.ToLower(match[1]),
+		Payload:    originalPayload,
+	}
+
+	if price, err := decimal.NewFromString(match[3]); err == nil {
+		req.Price = decimal.NewNullDecimal(price)
+	}
+	if match[4] != "" {
+		if sl, err := decimal.NewFromString(match[4]); err == nil {
+			req.StopLoss = decimal.NewNullDecimal(sl)
+		}
+	}
+	if match[5] != "" {
+		if tp, err := decimal.NewFromString(match[5]); err == nil {
+			req.TakeProfit = decimal.NewNullDecimal(tp)
+		}
+	}
+
+	return req, nil
+}
+
+// FixLiteParser handles a FIX-lite text alert format, a flat list of
+// semicolon-separated key=value pairs, e.g.:
+//
+//	SYMBOL=EURUSD;SIDE=BUY;QTY=0.1;SL=1.0800;TP1=1.0900;TP2=1.1000
+type FixLiteParser struct{}
+
+// Parse implements SignalParser for FIX-lite alert text.
+func (f *FixLiteParser) Parse(ctx context.Context, headers http.Header, body []byte) (*database.CreateSignalRequest, error) {
+	fields := make(map[string]string)
+	for _, pair := range strings.Split(strings.TrimSpace(string(body)), ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("fixlite: malformed field %q", pair)
+		}
+		fields[strings.ToUpper(strings.TrimSpace(kv[0]))] = strings.TrimSpace(kv[1])
+	}
+
+	symbol := fields["SYMBOL"]
+	side := fields["SIDE"]
+	if symbol == "" {
+		return nil, fmt.Errorf("fixlite: missing SYMBOL field")
+	}
+	if side == "" {
+		return nil, fmt.Errorf("fixlite: missing SIDE field")
+	}
+
+	req := &database.CreateSignalRequest{
+		Source:     "fixlite",
+		Symbol:     strings.ToUpper(symbol),
+		SignalType: strings.ToLower(side),
+		Payload:    body,
+	}
+
+	// QTY has no home on CreateSignalRequest today - like the Discord and
+	// Telegram parsers, volume sizing for non-TradingView sources is left
+	// to Processor.calculatePositionSize rather than the raw alert.
+	req.StopLoss = fixLiteFloat(fields, "SL")
+	req.TakeProfit = fixLiteFloat(fields, "TP")
+	req.TP1 = fixLiteFloat(fields, "TP1")
+	req.TP2 = fixLiteFloat(fields, "TP2")
+
+	return req, nil
+}
+
+func fixLiteFloat(fields map[string]string, key string) decimal.NullDecimal {
+	v, ok := fields[key]
+	if !ok {
+		return decimal.NullDecimal{}
+	}
+	f, err := decimal.NewFromString(v)
+	if err != nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NewNullDecimal(f)
+}
+
+// MT5CSVParser handles a MetaTrader-style CSV alert exported by EAs/scripts
+// that can only emit a single comma-separated line:
+//
+//	symbol,side,volume,stop_loss,tp1,tp2
+//
+// volume and tp2 may be left blank.
+type MT5CSVParser struct{}
+
+// Parse implements SignalParser for MetaTrader-style CSV alerts.
+func (m *MT5CSVParser) Parse(ctx context.Context, headers http.Header, body []byte) (*database.CreateSignalRequest, error) {
+	line := strings.TrimSpace(string(body))
+	if idx := strings.IndexAny(line, "\r\n"); idx != -1 {
+		line = line[:idx]
+	}
+
+	cols := strings.Split(line, ",")
+	if len(cols) < 4 {
+		return nil, fmt.Errorf("mt5csv: expected at least 4 columns (symbol,side,volume,stop_loss), got %d", len(cols))
+	}
+	for i := range cols {
+		cols[i] = strings.TrimSpace(cols[i])
+	}
+
+	symbol, side := cols[0], cols[1]
+	if symbol == "" {
+		return nil, fmt.Errorf("mt5csv: missing symbol column")
+	}
+	if side == "" {
+		return nil, fmt.Errorf("mt5csv: missing side column")
+	}
+
+	req := &database.CreateSignalRequest{
+		Source:     "mt5csv",
+		Symbol:     strings.ToUpper(symbol),
+		SignalType: strings.ToLower(side),
+		Payload:    body,
+	}
+
+	req.StopLoss = csvColumnFloat(cols, 3)
+	req.TP1 = csvColumnFloat(cols, 4)
+	req.TP2 = csvColumnFloat(cols, 5)
+
+	return req, nil
+}
+
+func csvColumnFloat(cols []string, index int) decimal.NullDecimal {
+	if index >= len(cols) || cols[index] == "" {
+		return decimal.NullDecimal{}
+	}
+	f, err := decimal.NewFromString(cols[index])
+	if err != nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NewNullDecimal(f)
+}
+
+func unmarshalJSONMap(body []byte) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func floatFieldFromMap(raw map[string]interface{}, field string) decimal.NullDecimal {
+	switch v := raw[field].(type) {
+	case float64:
+		return decimal.NewNullDecimal(decimal.NewFromFloat(v))
+	case string:
+		if f, err := decimal.NewFromString(v); err == nil {
+			return decimal.NewNullDecimal(f)
+		}
+	}
+	return decimal.NullDecimal{}
+}
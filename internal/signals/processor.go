@@ -2,32 +2,107 @@ package signals
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"trading-system/internal/config"
 	"trading-system/internal/database"
+	"trading-system/internal/exchange"
+	"trading-system/internal/execution"
+	"trading-system/internal/instrument"
+	"trading-system/internal/logging"
 	"trading-system/internal/mt5"
+	"trading-system/internal/router"
+	"trading-system/internal/strategy/dca"
 )
 
+// maxSignalPrice is the largest magnitude a price field can hold in the
+// signals table's DECIMAL(20,8) columns - 12 digits before the decimal
+// point, 8 after.
+var maxSignalPrice = decimal.RequireFromString("999999999999.99999999")
+
 // Processor handles signal processing and trade execution
 type Processor struct {
-	db        *database.DB
-	config    *config.Config
-	mt5Client *mt5.Client
+	db         *database.DB
+	config     *config.Config
+	mt5Client  *mt5.Client
+	router     *router.Router
+	execEngine *execution.Engine
+	aggregator *Aggregator
+	riskGuard  *RiskGuard
+	volGuard   *VolumeGuard
+	catalog    *instrument.Catalog
 }
 
 // New creates a new signal processor
 func New(db *database.DB, cfg *config.Config) *Processor {
-	return &Processor{
-		db:        db,
-		config:    cfg,
-		mt5Client: mt5.NewClient(&cfg.MT5),
+	RegisterParser("tradingview", &TradingViewParser{
+		MaxAge: time.Duration(cfg.Server.WebhookMaxAgeSeconds) * time.Second,
+	})
+
+	mt5Client := mt5.NewClient(&cfg.MT5)
+
+	p := &Processor{
+		db:         db,
+		config:     cfg,
+		mt5Client:  mt5Client,
+		router:     newExchangeRouter(&cfg.Exchanges),
+		execEngine: execution.NewEngine(mt5Client, db),
+		riskGuard:  NewRiskGuard(db, mt5Client, cfg.Risk),
+		volGuard:   NewVolumeGuard(db, cfg.Budget),
+		catalog: instrument.NewCatalog(mt5Client, db,
+			time.Duration(cfg.Instrument.SpecsTTLSeconds)*time.Second,
+			cfg.Instrument.StrictPrecision == "strict"),
+	}
+
+	if cfg.Aggregator.Enabled {
+		p.aggregator = NewAggregator(cfg.Aggregator)
+	}
+
+	return p
+}
+
+// newExchangeRouter registers an adapter for every enabled venue in cfg.
+func newExchangeRouter(cfg *config.ExchangesConfig) *router.Router {
+	r := router.New()
+
+	if cfg.Bybit.Enabled {
+		r.Register(exchange.NewBybit(exchange.BybitConfig{
+			Endpoint:   cfg.Bybit.Endpoint,
+			APIKey:     cfg.Bybit.APIKey,
+			APISecret:  cfg.Bybit.APISecret,
+			RecvWindow: cfg.Bybit.RecvWindow,
+		}), "BYBIT:")
+	}
+
+	if cfg.OKX.Enabled {
+		r.Register(exchange.NewOKX(exchange.OKXConfig{
+			Endpoint:   cfg.OKX.Endpoint,
+			APIKey:     cfg.OKX.APIKey,
+			APISecret:  cfg.OKX.APISecret,
+			Passphrase: cfg.OKX.Passphrase,
+		}), "OKX:")
+	}
+
+	if cfg.Binance.Enabled {
+		r.Register(exchange.NewBinance(exchange.BinanceConfig{
+			Endpoint:  cfg.Binance.Endpoint,
+			APIKey:    cfg.Binance.APIKey,
+			APISecret: cfg.Binance.APISecret,
+		}), "BINANCE:")
 	}
+
+	return r
 }
 
 // GetMT5Client returns the MT5 client for external use
@@ -35,6 +110,45 @@ func (p *Processor) GetMT5Client() *mt5.Client {
 	return p.mt5Client
 }
 
+// GetRouter returns the exchange router for external use
+func (p *Processor) GetRouter() *router.Router {
+	return p.router
+}
+
+// ResetRiskGuard clears a tripped RiskGuard kill-switch, for the manual
+// admin reset endpoint.
+func (p *Processor) ResetRiskGuard(ctx context.Context) error {
+	return p.riskGuard.Reset(ctx)
+}
+
+// GetBudgetStatus reports today's accumulated volume/fees for the admin
+// budget status endpoint.
+func (p *Processor) GetBudgetStatus(ctx context.Context) (*BudgetStatus, error) {
+	return p.volGuard.Status(ctx)
+}
+
+// auditLog persists an audit trail entry to SystemLog, stamped with ctx's
+// correlation ID (if any). See server.Server.auditLog for the other half
+// of the ingress -> parse -> risk check -> MT5 submit trail this supports.
+func (p *Processor) auditLog(ctx context.Context, level, message, component string, fields map[string]interface{}) {
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	if id := logging.CorrelationID(ctx); id != "" {
+		fields["correlation_id"] = id
+	}
+
+	contextData, err := json.Marshal(fields)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to marshal audit log context", "error", err)
+		return
+	}
+
+	if err := p.db.LogEvent(ctx, level, message, component, contextData); err != nil {
+		logging.FromContext(ctx).Error("failed to write audit log", "error", err)
+	}
+}
+
 // Start begins the signal processing loop and position monitoring
 func (p *Processor) Start(ctx context.Context) {
 	log.Println("Starting signal processor...")
@@ -52,6 +166,9 @@ func (p *Processor) Start(ctx context.Context) {
 	// Start position monitoring goroutine
 	go p.positionMonitoringLoop(ctx)
 
+	// Start idempotency record purging goroutine
+	go p.idempotencyPurgeLoop(ctx)
+
 	// Keep the processor running
 	<-ctx.Done()
 	log.Println("Signal processor stopped")
@@ -130,6 +247,19 @@ func (p *Processor) processUnprocessedSignals(ctx context.Context) error {
 func (p *Processor) processSignal(ctx context.Context, signal *database.Signal) error {
 	log.Printf("Processing signal %d: %s %s on %s", signal.ID, signal.SignalType, signal.Symbol, signal.Source)
 
+	// When the multi-source aggregator is enabled, gate every non-close
+	// signal on weighted consensus across sources (TradingView, custom
+	// HTTP, MT5 indicators) before it's allowed to create a trade.
+	if p.aggregator != nil && signal.SignalType != "close" {
+		triggered, err := p.gateOnConsensus(ctx, signal)
+		if err != nil {
+			log.Printf("Failed to record aggregator consensus for signal %d: %v", signal.ID, err)
+		}
+		if !triggered {
+			return nil
+		}
+	}
+
 	// Parse TradingView webhook if applicable
 	if signal.Source == "tradingview" {
 		return p.processTradingViewSignal(ctx, signal)
@@ -139,6 +269,36 @@ func (p *Processor) processSignal(ctx context.Context, signal *database.Signal)
 	return fmt.Errorf("unsupported signal source: %s", signal.Source)
 }
 
+// gateOnConsensus records signal's vote with the aggregator and embeds the
+// resulting provenance (sources, weights, score) into the signal's
+// persisted payload for later analysis. It returns whether this call is
+// the transition into "reached" - i.e. whether processing should proceed
+// now - not merely whether consensus currently holds, so a majority that's
+// already been acted on doesn't re-trigger on every subsequent vote (see
+// Aggregator.Record).
+func (p *Processor) gateOnConsensus(ctx context.Context, signal *database.Signal) (bool, error) {
+	consensus, triggered := p.aggregator.Record(signal.Symbol, signal.SignalType, signal.Source, time.Now())
+
+	p.auditLog(ctx, "info", "aggregator consensus updated", "aggregator", map[string]interface{}{
+		"signal_id": signal.ID, "symbol": signal.Symbol, "direction": signal.SignalType,
+		"score": consensus.Score, "threshold": consensus.Threshold, "reached": consensus.Reached, "triggered": triggered,
+	})
+
+	payload := embedConsensus(signal.Payload, consensus)
+	if err := p.db.UpdateSignalPayload(ctx, signal.ID, payload); err != nil {
+		return triggered, fmt.Errorf("failed to persist consensus provenance: %w", err)
+	}
+	signal.Payload = payload
+
+	if !consensus.Reached {
+		log.Printf("Signal %d: aggregator consensus not yet reached (%.2f/%.2f), holding", signal.ID, consensus.Score, consensus.Threshold)
+	} else if !triggered {
+		log.Printf("Signal %d: aggregator consensus already acted on for this majority, holding", signal.ID)
+	}
+
+	return triggered, nil
+}
+
 // processTradingViewSignal processes a signal from TradingView
 func (p *Processor) processTradingViewSignal(ctx context.Context, signal *database.Signal) error {
 	var tvWebhook database.TradingViewWebhook
@@ -146,11 +306,33 @@ func (p *Processor) processTradingViewSignal(ctx context.Context, signal *databa
 		return fmt.Errorf("failed to parse TradingView webhook: %w", err)
 	}
 
+	// Re-attach the correlation ID stamped at webhook ingress (see
+	// embedCorrelationID) so audit log rows from this point on - risk
+	// check, MT5/exchange submit - still join the same trace.
+	if tvWebhook.CorrelationID != "" {
+		ctx = logging.ContextWithCorrelationID(ctx, tvWebhook.CorrelationID)
+	}
+
 	// Validate signal type
 	if signal.SignalType == "close" {
 		return p.handleCloseSignal(ctx, signal, &tvWebhook)
 	}
 
+	// Route to a non-MT5 execution venue if one resolves for this signal
+	if adapter, err := p.router.Resolve(tvWebhook.Exchange, signal.Symbol); err == nil {
+		return p.processExchangeSignal(ctx, signal, &tvWebhook, adapter)
+	}
+
+	// A tripped circuit breaker means the bridge has already failed
+	// CircuitThreshold times in a row - skip straight away rather than
+	// spending an IsConnected round trip and a full SendTrade retry
+	// budget on a bridge we already know is down.
+	if p.mt5Client.State() == mt5.CircuitOpen {
+		log.Printf("MT5 circuit breaker open - skipping signal %d", signal.ID)
+		p.auditLog(ctx, "warn", "skipped_bridge_down", "signals", map[string]interface{}{"signal_id": signal.ID})
+		return nil
+	}
+
 	// Check MT5 connectivity first - don't create trades if not connected
 	if !p.mt5Client.IsConnected(ctx) {
 		log.Printf("MT5 bridge not available - rejecting signal %d", signal.ID)
@@ -166,82 +348,477 @@ func (p *Processor) processTradingViewSignal(ctx context.Context, signal *databa
 	// Apply risk management checks first
 	if p.config.Risk.EnableRiskChecks {
 		if err := p.validateRiskParametersFromSignal(ctx, signal, requestedVolume); err != nil {
+			p.auditLog(ctx, "error", "risk check rejected signal", "signals", map[string]interface{}{"signal_id": signal.ID, "error": err.Error()})
 			return fmt.Errorf("risk validation failed: %w", err)
 		}
 	}
+	p.auditLog(ctx, "info", "risk check passed", "signals", map[string]interface{}{"signal_id": signal.ID})
 
 	// Calculate position size
 	totalVolume := p.calculatePositionSize(signal.Symbol, requestedVolume)
 
+	// Apply the configured ReverseSignalAction if this signal opposes an
+	// existing open position for the symbol.
+	hedgeOf, totalVolume, err := p.applyReverseSignalAction(ctx, signal, totalVolume)
+	if err != nil {
+		return fmt.Errorf("failed to apply reverse signal action: %w", err)
+	}
+	if totalVolume <= 0 {
+		return nil
+	}
+
+	if err := p.volGuard.Check(ctx, signal.Symbol, p.projectedNotional(signal, totalVolume)); err != nil {
+		p.auditLog(ctx, "error", "budget check rejected signal", "signals", map[string]interface{}{"signal_id": signal.ID, "error": err.Error()})
+		return fmt.Errorf("budget validation failed: %w", err)
+	}
+
+	// A signal tagged strategy=dca skips the single entry trade below
+	// entirely and instead has its budget laddered across multiple limit
+	// legs by the DCA planner.
+	if tvWebhook.Strategy == "dca" {
+		return p.processDCASignal(ctx, signal, &tvWebhook)
+	}
+
 	// Create entry trade
-	entryTrade, err := p.createEntryTrade(ctx, signal, totalVolume)
+	entryTrade, err := p.createEntryTrade(ctx, signal, totalVolume, hedgeOf)
 	if err != nil {
 		return fmt.Errorf("failed to create entry trade: %w", err)
 	}
+	if err := p.volGuard.RecordEntryVolume(ctx, p.projectedNotional(signal, totalVolume)); err != nil {
+		log.Printf("Warning: failed to record budget volume for trade %d: %v", entryTrade.ID, err)
+	}
 
 	log.Printf("Created entry trade %d for signal %d: %s %s %.2f lots",
 		entryTrade.ID, signal.ID, entryTrade.Direction, entryTrade.Symbol, entryTrade.Volume)
 
+	// A signal opting into sliced execution skips the single-shot MT5 entry
+	// order below and instead has its volume worked over time by the
+	// execution engine in the background; TP orders are left to the user's
+	// own risk management since the entry itself fills incrementally.
+	if tvWebhook.Execution != nil {
+		return p.executeSlicedEntryTrade(ctx, signal, entryTrade, tvWebhook.Execution)
+	}
+
+	p.auditLog(ctx, "info", "submitting entry trade to MT5", "mt5", map[string]interface{}{"signal_id": signal.ID, "trade_id": entryTrade.ID})
+
 	// Execute entry trade via MT5 (no TP levels, just entry with SL)
 	if err := p.executeEntryTrade(ctx, entryTrade); err != nil {
 		log.Printf("Failed to execute entry trade %d: %v", entryTrade.ID, err)
 		p.updateTradeStatus(ctx, entryTrade.ID, "rejected", nil)
 		log.Printf("Trade %d marked as rejected due to execution failure", entryTrade.ID)
+		p.auditLog(ctx, "error", "MT5 entry trade execution failed", "mt5", map[string]interface{}{"signal_id": signal.ID, "trade_id": entryTrade.ID, "error": err.Error()})
 		return fmt.Errorf("entry trade execution failed: %w", err)
 	}
+	p.auditLog(ctx, "info", "MT5 entry trade submitted", "mt5", map[string]interface{}{"signal_id": signal.ID, "trade_id": entryTrade.ID})
+
+	if tvWebhook.Hedge != nil {
+		p.openHedgeLeg(ctx, signal, entryTrade, tvWebhook.Hedge)
+	}
+
+	// TP1/TP2 orders are not placed here: executeEntryTrade's response can
+	// report success before MT5 has actually confirmed a fill (or confirm
+	// only a partial fill), and sizing TPs off the requested volume rather
+	// than the real one is exactly what leaves them oversized. Instead,
+	// createTPOrdersIfFilled places them from syncPositionsFromMT5 once the
+	// position is observed open, using its actual filled volume.
+
+	return nil
+}
+
+// processDCASignal lays out signal's DCA ladder via dca.Plan, batch-inserts
+// the resulting legs, and submits them to MT5 in one round trip via
+// SendTradeBatch. Unlike the single-entry flow, no separate TP orders are
+// placed afterwards - each leg already carries the ladder's shared take
+// profit directly on its own order (see dca.Plan) - and cancellation of
+// any still-unfilled legs once that TP fires is handled by
+// cancelSiblingDCALegs from syncPositionsFromMT5.
+func (p *Processor) processDCASignal(ctx context.Context, signal *database.Signal, webhook *database.TradingViewWebhook) error {
+	if webhook.DCA == nil {
+		return fmt.Errorf("signal %d tagged strategy=dca but missing dca config", signal.ID)
+	}
+
+	cfg, err := dca.ParseConfig(webhook.DCA)
+	if err != nil {
+		return fmt.Errorf("invalid dca config: %w", err)
+	}
+
+	legReqs, err := dca.Plan(signal, *cfg)
+	if err != nil {
+		return fmt.Errorf("failed to plan dca ladder: %w", err)
+	}
 
-	// Only proceed with TP orders if entry was successful
-	log.Printf("Entry trade %d successfully executed, creating TP orders...", entryTrade.ID)
+	legs, err := p.db.CreateTradesBatch(ctx, legReqs)
+	if err != nil {
+		return fmt.Errorf("failed to create dca legs: %w", err)
+	}
+	log.Printf("Signal %d: planned %d DCA legs", signal.ID, len(legs))
 
-	// Add a small delay to ensure MT5 processes the entry trade before creating TP orders
-	time.Sleep(500 * time.Millisecond)
+	if p.mt5Client.State() == mt5.CircuitOpen || !p.mt5Client.IsConnected(ctx) {
+		log.Printf("MT5 bridge not available - leaving %d DCA legs pending for the next sync", len(legs))
+		return nil
+	}
 
-	// Create and execute TP1 order if available
-	if signal.TP1 != nil && *signal.TP1 > 0 {
-		tp1Volume := totalVolume / 2 // 50% for TP1
-		tp1Trade, err := p.createTPTradeWithRetry(ctx, signal, entryTrade.ID, "tp1", *signal.TP1, tp1Volume)
+	mt5Reqs := make([]*mt5.TradeRequest, len(legs))
+	for i, leg := range legs {
+		mt5Req := &mt5.TradeRequest{
+			Symbol:    leg.Symbol,
+			Action:    leg.Direction,
+			Volume:    leg.Volume,
+			OrderType: leg.OrderType,
+		}
+		if leg.EntryPrice != nil {
+			mt5Req.Price = *leg.EntryPrice
+		}
+		if leg.TakeProfit != nil {
+			mt5Req.TakeProfit = *leg.TakeProfit
+		}
+
+		normPrice, normVolume, err := p.catalog.Normalize(ctx, mt5Req.Symbol, mt5Req.Price, mt5Req.Volume)
 		if err != nil {
-			log.Printf("Failed to create TP1 trade: %v", err)
+			return fmt.Errorf("failed to normalize dca leg %d for %s: %w", leg.ID, mt5Req.Symbol, err)
+		}
+		mt5Req.Price = normPrice
+		mt5Req.Volume = normVolume
+
+		mt5Reqs[i] = mt5Req
+	}
+
+	responses, err := p.mt5Client.SendTradeBatch(ctx, "dca", mt5Reqs)
+	if err != nil {
+		return fmt.Errorf("failed to submit dca batch: %w", err)
+	}
+
+	for i, leg := range legs {
+		resp := responses[i]
+		responseData, _ := json.Marshal(resp)
+		responseRaw := json.RawMessage(responseData)
+		updateReq := &database.UpdateTradeStatusRequest{MT5Response: &responseRaw}
+
+		if resp.Success {
+			updateReq.Status = "pending" // resting limit order, waiting to fill
+			if resp.Ticket != 0 {
+				updateReq.MT5Ticket = &resp.Ticket
+			}
 		} else {
-			log.Printf("Created TP1 trade %d for signal %d", tp1Trade.ID, signal.ID)
+			updateReq.Status = "rejected"
+			log.Printf("DCA leg %d rejected: %s", leg.ID, resp.ErrorMsg)
+		}
 
-			// Execute TP1 limit order
-			if err := p.executeTPTrade(ctx, tp1Trade); err != nil {
-				log.Printf("Failed to execute TP1 trade %d: %v", tp1Trade.ID, err)
-				p.updateTradeStatus(ctx, tp1Trade.ID, "rejected", nil)
-			} else {
-				log.Printf("TP1 trade %d successfully placed", tp1Trade.ID)
+		if err := p.db.UpdateTradeStatus(ctx, leg.ID, updateReq); err != nil {
+			log.Printf("Failed to update dca leg %d: %v", leg.ID, err)
+			continue
+		}
+		if resp.Success {
+			if err := p.volGuard.RecordEntryVolume(ctx, p.projectedNotional(signal, leg.Volume)); err != nil {
+				log.Printf("Warning: failed to record budget volume for dca leg %d: %v", leg.ID, err)
 			}
 		}
 	}
 
-	// Create and execute TP2 order if available
-	if signal.TP2 != nil && *signal.TP2 > 0 {
-		tp2Volume := totalVolume / 2 // 50% for TP2
-		tp2Trade, err := p.createTPTradeWithRetry(ctx, signal, entryTrade.ID, "tp2", *signal.TP2, tp2Volume)
-		if err != nil {
-			log.Printf("Failed to create TP2 trade: %v", err)
-		} else {
-			log.Printf("Created TP2 trade %d for signal %d", tp2Trade.ID, signal.ID)
+	return nil
+}
 
-			// Execute TP2 limit order
-			if err := p.executeTPTrade(ctx, tp2Trade); err != nil {
-				log.Printf("Failed to execute TP2 trade %d: %v", tp2Trade.ID, err)
-				p.updateTradeStatus(ctx, tp2Trade.ID, "rejected", nil)
-			} else {
-				log.Printf("TP2 trade %d successfully placed", tp2Trade.ID)
-			}
+// cancelSiblingDCALegs cancels every still-pending dca_leg order sharing
+// filledLeg's parent signal once filledLeg itself stops appearing in MT5 -
+// i.e. the ladder's shared take profit fired and closed the averaged
+// position, so the remaining unfilled legs are no longer wanted. There is
+// no parent-trade hierarchy among DCA legs (they're siblings under one
+// signal, not a tree), so this reuses GetOpenTrades filtered by
+// ParentSignalID rather than GetTradesByParent, which keys on a parent
+// trade ID.
+func (p *Processor) cancelSiblingDCALegs(ctx context.Context, filledLeg *database.Trade) {
+	if filledLeg.ParentSignalID == nil {
+		return
+	}
+
+	openTrades, err := p.db.GetOpenTrades(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to get open trades while cancelling sibling dca legs: %v", err)
+		return
+	}
+
+	for _, sibling := range openTrades {
+		if sibling.ID == filledLeg.ID || sibling.TradeType != "dca_leg" || sibling.Status != "pending" {
+			continue
+		}
+		if sibling.ParentSignalID == nil || *sibling.ParentSignalID != *filledLeg.ParentSignalID {
+			continue
+		}
+		if sibling.MT5Ticket == nil {
+			continue
+		}
+
+		if err := p.mt5Client.CancelOrder(ctx, *sibling.MT5Ticket); err != nil {
+			log.Printf("Warning: failed to cancel sibling dca leg %d (MT5 ticket %d): %v", sibling.ID, *sibling.MT5Ticket, err)
+			continue
+		}
+		p.updateTradeStatus(ctx, sibling.ID, "cancelled", nil)
+	}
+}
+
+// processExchangeSignal executes a signal on a non-MT5 venue via the
+// router-resolved adapter. TP1/TP2 ladder orders are not yet supported on
+// exchange adapters; only the entry leg is placed.
+func (p *Processor) processExchangeSignal(ctx context.Context, signal *database.Signal, webhook *database.TradingViewWebhook, adapter exchange.ExchangeClient) error {
+	if !adapter.IsConnected(ctx) {
+		return fmt.Errorf("%s adapter not available - cannot execute trades", adapter.Name())
+	}
+
+	requestedVolume := 0.0
+	if webhook.Volume != nil {
+		requestedVolume = *webhook.Volume
+	}
+
+	if p.config.Risk.EnableRiskChecks {
+		if err := p.validateRiskParametersFromSignal(ctx, signal, requestedVolume); err != nil {
+			p.auditLog(ctx, "error", "risk check rejected signal", "signals", map[string]interface{}{"signal_id": signal.ID, "error": err.Error()})
+			return fmt.Errorf("risk validation failed: %w", err)
 		}
 	}
+	p.auditLog(ctx, "info", "risk check passed", "signals", map[string]interface{}{"signal_id": signal.ID})
+
+	volume := p.calculatePositionSize(signal.Symbol, requestedVolume)
+
+	if err := p.volGuard.Check(ctx, signal.Symbol, p.projectedNotional(signal, volume)); err != nil {
+		p.auditLog(ctx, "error", "budget check rejected signal", "signals", map[string]interface{}{"signal_id": signal.ID, "error": err.Error()})
+		return fmt.Errorf("budget validation failed: %w", err)
+	}
+
+	entryTrade, err := p.createEntryTrade(ctx, signal, volume, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create entry trade: %w", err)
+	}
+	if err := p.volGuard.RecordEntryVolume(ctx, p.projectedNotional(signal, volume)); err != nil {
+		log.Printf("Warning: failed to record budget volume for trade %d: %v", entryTrade.ID, err)
+	}
+
+	p.auditLog(ctx, "info", "submitting entry order to exchange adapter", "exchange", map[string]interface{}{"signal_id": signal.ID, "trade_id": entryTrade.ID, "adapter": adapter.Name()})
+
+	order := &exchange.Order{
+		Symbol:    signal.Symbol,
+		Side:      signal.SignalType,
+		Volume:    volume,
+		OrderType: "market",
+	}
+	if signal.Price != nil {
+		order.Price = *signal.Price
+	}
+	if signal.StopLoss != nil {
+		order.StopLoss = *signal.StopLoss
+	}
+
+	result, err := adapter.PlaceOrder(ctx, order)
+	if err != nil {
+		p.updateTradeStatus(ctx, entryTrade.ID, "rejected", nil)
+		p.auditLog(ctx, "error", "exchange order placement failed", "exchange", map[string]interface{}{"signal_id": signal.ID, "trade_id": entryTrade.ID, "adapter": adapter.Name(), "error": err.Error()})
+		return fmt.Errorf("failed to place order on %s: %w", adapter.Name(), err)
+	}
+	p.auditLog(ctx, "info", "exchange order filled", "exchange", map[string]interface{}{"signal_id": signal.ID, "trade_id": entryTrade.ID, "adapter": adapter.Name(), "order_id": result.OrderID})
+
+	responseData, _ := json.Marshal(result)
+	responseRaw := json.RawMessage(responseData)
+	updateReq := &database.UpdateTradeStatusRequest{
+		Status:      "filled",
+		MT5Response: &responseRaw,
+	}
+	if result.Price != 0 {
+		updateReq.EntryPrice = &result.Price
+	}
+
+	log.Printf("Placed entry order %s on %s for signal %d: %s %s %.2f",
+		result.OrderID, adapter.Name(), signal.ID, signal.SignalType, signal.Symbol, volume)
+
+	if err := p.db.UpdateTradeStatus(ctx, entryTrade.ID, updateReq); err != nil {
+		return err
+	}
+
+	if webhook.Hedge != nil {
+		p.openHedgeLeg(ctx, signal, entryTrade, webhook.Hedge)
+	}
 
 	return nil
 }
 
-// createEntryTrade creates the main entry trade
-func (p *Processor) createEntryTrade(ctx context.Context, signal *database.Signal, volume float64) (*database.Trade, error) {
+// openHedgeLeg places an opposite-side order on a second router-registered
+// exchange adapter for a signal carrying a HedgeConfig, recording it as a
+// trade linked to primaryTrade via HedgeOf. This is a best-effort leg: a
+// failure here is logged rather than returned, since the primary trade has
+// already been filled and rolling it back would be worse than running
+// unhedged.
+func (p *Processor) openHedgeLeg(ctx context.Context, signal *database.Signal, primaryTrade *database.Trade, hedge *database.HedgeConfig) {
+	if hedge.Session == "" || hedge.Symbol == "" || hedge.Ratio <= 0 {
+		log.Printf("Hedge leg for trade %d skipped: incomplete hedge config", primaryTrade.ID)
+		return
+	}
+
+	adapter, err := p.router.Get(hedge.Session)
+	if err != nil {
+		log.Printf("Hedge leg for trade %d not opened: %v", primaryTrade.ID, err)
+		return
+	}
+	if !adapter.IsConnected(ctx) {
+		log.Printf("Hedge leg for trade %d not opened: %s adapter not connected", primaryTrade.ID, hedge.Session)
+		return
+	}
+
+	hedgeSide := "sell"
+	if primaryTrade.Direction == "sell" {
+		hedgeSide = "buy"
+	}
+	hedgeVolume := primaryTrade.Volume * hedge.Ratio
+
+	hedgeTrade, err := p.db.CreateTrade(ctx, &database.CreateTradeRequest{
+		SignalID:       &signal.ID,
+		ParentSignalID: &signal.ID,
+		HedgeOf:        &primaryTrade.ID,
+		TradeType:      "entry",
+		Symbol:         hedge.Symbol,
+		OrderType:      "market",
+		Direction:      hedgeSide,
+		Volume:         hedgeVolume,
+	})
+	if err != nil {
+		log.Printf("Failed to create hedge trade record for trade %d: %v", primaryTrade.ID, err)
+		return
+	}
+
+	p.auditLog(ctx, "info", "submitting hedge leg to exchange adapter", "exchange", map[string]interface{}{
+		"signal_id": signal.ID, "primary_trade_id": primaryTrade.ID, "hedge_trade_id": hedgeTrade.ID, "adapter": adapter.Name(),
+	})
+
+	result, err := adapter.PlaceOrder(ctx, &exchange.Order{
+		Symbol:    hedge.Symbol,
+		Side:      hedgeSide,
+		Volume:    hedgeVolume,
+		OrderType: "market",
+		Leverage:  hedge.Leverage,
+	})
+	if err != nil {
+		p.updateTradeStatus(ctx, hedgeTrade.ID, "rejected", nil)
+		log.Printf("Failed to place hedge leg for trade %d on %s: %v", primaryTrade.ID, hedge.Session, err)
+		return
+	}
+
+	responseData, _ := json.Marshal(result)
+	responseRaw := json.RawMessage(responseData)
+	updateReq := &database.UpdateTradeStatusRequest{Status: "filled", MT5Response: &responseRaw}
+	if result.Price != 0 {
+		updateReq.EntryPrice = &result.Price
+	}
+	if err := p.db.UpdateTradeStatus(ctx, hedgeTrade.ID, updateReq); err != nil {
+		log.Printf("Failed to persist hedge leg fill for trade %d: %v", primaryTrade.ID, err)
+		return
+	}
+
+	log.Printf("Opened hedge leg for trade %d on %s: %s %s %.4f (ratio %.2f)",
+		primaryTrade.ID, hedge.Session, hedgeSide, hedge.Symbol, hedgeVolume, hedge.Ratio)
+}
+
+// closeHedgeLeg closes out any still-open hedge legs linked to primaryTrade
+// (see openHedgeLeg) once the primary trade itself closes, so TP fills or
+// stop-outs on one side of a hedged signal don't leave the other side
+// running naked.
+func (p *Processor) closeHedgeLeg(ctx context.Context, primaryTrade *database.Trade) {
+	hedgeTrades, err := p.db.GetTradesByHedgeOf(ctx, primaryTrade.ID)
+	if err != nil {
+		log.Printf("Failed to look up hedge legs for trade %d: %v", primaryTrade.ID, err)
+		return
+	}
+
+	for _, hedgeTrade := range hedgeTrades {
+		if hedgeTrade.Status != "filled" {
+			continue
+		}
+
+		adapter, err := p.router.Resolve("", hedgeTrade.Symbol)
+		if err != nil {
+			log.Printf("Failed to close hedge leg %d for trade %d: %v", hedgeTrade.ID, primaryTrade.ID, err)
+			continue
+		}
+
+		closeSide := "sell"
+		if hedgeTrade.Direction == "sell" {
+			closeSide = "buy"
+		}
+
+		if _, err := adapter.PlaceOrder(ctx, &exchange.Order{
+			Symbol:    hedgeTrade.Symbol,
+			Side:      closeSide,
+			Volume:    hedgeTrade.Volume,
+			OrderType: "market",
+		}); err != nil {
+			log.Printf("Failed to close hedge leg %d for trade %d: %v", hedgeTrade.ID, primaryTrade.ID, err)
+			continue
+		}
+
+		p.updateTradeStatus(ctx, hedgeTrade.ID, "closed", nil)
+		log.Printf("Closed hedge leg %d for trade %d on %s", hedgeTrade.ID, primaryTrade.ID, adapter.Name())
+	}
+}
+
+// reduceHedgeLeg partially closes any still-open hedge legs linked to
+// primaryTrade (see openHedgeLeg) by the same proportion the primary trade
+// itself just shrank by, e.g. when a TP1 fire partially closes the primary
+// position. originalVolume is the primary trade's volume before this
+// reduction; closedVolume is how much of it just closed.
+func (p *Processor) reduceHedgeLeg(ctx context.Context, primaryTrade *database.Trade, closedVolume, originalVolume float64) {
+	if closedVolume <= 0 || originalVolume <= 0 {
+		return
+	}
+
+	hedgeTrades, err := p.db.GetTradesByHedgeOf(ctx, primaryTrade.ID)
+	if err != nil {
+		log.Printf("Failed to look up hedge legs for trade %d: %v", primaryTrade.ID, err)
+		return
+	}
+
+	closedFraction := closedVolume / originalVolume
+
+	for _, hedgeTrade := range hedgeTrades {
+		if hedgeTrade.Status != "filled" {
+			continue
+		}
+
+		adapter, err := p.router.Resolve("", hedgeTrade.Symbol)
+		if err != nil {
+			log.Printf("Failed to reduce hedge leg %d for trade %d: %v", hedgeTrade.ID, primaryTrade.ID, err)
+			continue
+		}
+
+		reduceVolume := hedgeTrade.Volume * closedFraction
+		closeSide := "sell"
+		if hedgeTrade.Direction == "sell" {
+			closeSide = "buy"
+		}
+
+		if _, err := adapter.PlaceOrder(ctx, &exchange.Order{
+			Symbol:    hedgeTrade.Symbol,
+			Side:      closeSide,
+			Volume:    reduceVolume,
+			OrderType: "market",
+		}); err != nil {
+			log.Printf("Failed to reduce hedge leg %d for trade %d: %v", hedgeTrade.ID, primaryTrade.ID, err)
+			continue
+		}
+
+		remainingVolume := hedgeTrade.Volume - reduceVolume
+		if err := p.db.UpdateTradeStatus(ctx, hedgeTrade.ID, &database.UpdateTradeStatusRequest{Status: "filled", Volume: &remainingVolume}); err != nil {
+			log.Printf("Failed to persist reduced hedge leg %d for trade %d: %v", hedgeTrade.ID, primaryTrade.ID, err)
+			continue
+		}
+
+		log.Printf("Reduced hedge leg %d for trade %d by %.4f (%.0f%% of primary close) on %s",
+			hedgeTrade.ID, primaryTrade.ID, reduceVolume, closedFraction*100, adapter.Name())
+	}
+}
+
+// createEntryTrade creates the main entry trade. hedgeOf references the
+// trade being hedged when ReverseSignalAction is "hedge"; nil otherwise.
+func (p *Processor) createEntryTrade(ctx context.Context, signal *database.Signal, volume float64, hedgeOf *int) (*database.Trade, error) {
 	tradeReq := &database.CreateTradeRequest{
 		SignalID:       &signal.ID,
 		ParentSignalID: &signal.ID,
+		HedgeOf:        hedgeOf,
 		TradeType:      "entry",
 		Symbol:         signal.Symbol,
 		OrderType:      "market",
@@ -259,24 +836,33 @@ func (p *Processor) createEntryTrade(ctx context.Context, signal *database.Signa
 	return p.db.CreateTrade(ctx, tradeReq)
 }
 
-// createTPTrade creates a take profit trade
-func (p *Processor) createTPTrade(ctx context.Context, signal *database.Signal, parentTradeID int, tpType string, tpPrice float64, volume float64) (*database.Trade, error) {
+// createTPTrade creates a take profit trade against entryTrade, the filled
+// entry position it's scaling out of.
+func (p *Processor) createTPTrade(ctx context.Context, entryTrade *database.Trade, tpType string, tpPrice float64, volume float64) (*database.Trade, error) {
+	// Normalize before persisting, same as the MT5-facing paths - otherwise
+	// the DB row records an un-rounded price/volume the broker would never
+	// actually accept once executeTPTrade submits it.
+	normPrice, normVolume, err := p.catalog.Normalize(ctx, entryTrade.Symbol, tpPrice, volume)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize TP trade for %s: %w", entryTrade.Symbol, err)
+	}
+
 	tradeReq := &database.CreateTradeRequest{
-		SignalID:       &signal.ID,
-		ParentSignalID: &signal.ID,     // Reference the original signal
-		ParentTradeID:  &parentTradeID, // Reference the parent entry trade
+		SignalID:       entryTrade.SignalID,
+		ParentSignalID: entryTrade.ParentSignalID,
+		ParentTradeID:  &entryTrade.ID,
 		TradeType:      tpType,
-		Symbol:         signal.Symbol,
+		Symbol:         entryTrade.Symbol,
 		OrderType:      "limit",
-		Direction:      getOppositeDirection(signal.SignalType),
-		Volume:         volume,
-		EntryPrice:     &tpPrice,
-		StopLoss:       signal.StopLoss,
-		TakeProfit:     &tpPrice,
-		TP1:            signal.TP1,
-		TP2:            signal.TP2,
-		SL1:            signal.SL1,
-		SL2:            signal.SL2,
+		Direction:      getOppositeDirection(entryTrade.Direction),
+		Volume:         normVolume,
+		EntryPrice:     &normPrice,
+		StopLoss:       entryTrade.StopLoss,
+		TakeProfit:     &normPrice,
+		TP1:            entryTrade.TP1,
+		TP2:            entryTrade.TP2,
+		SL1:            entryTrade.SL1,
+		SL2:            entryTrade.SL2,
 	}
 
 	return p.db.CreateTrade(ctx, tradeReq)
@@ -301,6 +887,33 @@ func (p *Processor) updateTradeStatus(ctx context.Context, tradeID int, status s
 	}
 }
 
+// executeSlicedEntryTrade validates the webhook's execution config and, if
+// valid, hands trade off to the execution engine to be worked over time in
+// the background. It returns as soon as the run is scheduled; the engine
+// reports progress via execution_positions and structured logs, not via
+// the trade's own status.
+func (p *Processor) executeSlicedEntryTrade(ctx context.Context, signal *database.Signal, trade *database.Trade, execReq *database.ExecutionRequest) error {
+	execCfg, err := execution.ParseConfig(execReq)
+	if err != nil {
+		p.updateTradeStatus(ctx, trade.ID, "rejected", nil)
+		p.auditLog(ctx, "error", "invalid execution config", "execution", map[string]interface{}{"signal_id": signal.ID, "trade_id": trade.ID, "error": err.Error()})
+		return fmt.Errorf("invalid execution config: %w", err)
+	}
+
+	p.auditLog(ctx, "info", "starting sliced execution", "execution", map[string]interface{}{
+		"signal_id": signal.ID, "trade_id": trade.ID, "algo": execCfg.Algo, "slices": execCfg.Slices,
+	})
+
+	go func() {
+		if err := p.execEngine.Run(ctx, *execCfg, trade); err != nil {
+			log.Printf("Sliced execution failed for trade %d: %v", trade.ID, err)
+			p.auditLog(ctx, "error", "sliced execution failed", "execution", map[string]interface{}{"signal_id": signal.ID, "trade_id": trade.ID, "error": err.Error()})
+		}
+	}()
+
+	return nil
+}
+
 // executeEntryTrade sends the entry trade to MT5 for execution (no TP levels)
 func (p *Processor) executeEntryTrade(ctx context.Context, trade *database.Trade) error {
 	// Check if MT5 is connected
@@ -323,6 +936,16 @@ func (p *Processor) executeEntryTrade(ctx context.Context, trade *database.Trade
 	if trade.StopLoss != nil {
 		mt5Req.StopLoss = *trade.StopLoss
 	}
+
+	// Snap price/volume onto the symbol's tick grid before it ever reaches
+	// SendTrade - a price or volume computed in floating point can otherwise
+	// drift off what the broker will actually accept.
+	normPrice, normVolume, err := p.catalog.Normalize(ctx, mt5Req.Symbol, mt5Req.Price, mt5Req.Volume)
+	if err != nil {
+		return fmt.Errorf("failed to normalize trade for %s: %w", mt5Req.Symbol, err)
+	}
+	mt5Req.Price = normPrice
+	mt5Req.Volume = normVolume
 	// Note: No TakeProfit, TP1, TP2 - these will be separate orders
 
 	log.Printf("Sending entry trade to MT5: Symbol=%s, Action=%s, Volume=%.2f, Price=%.5f, SL=%.5f",
@@ -351,6 +974,13 @@ func (p *Processor) executeEntryTrade(ctx context.Context, trade *database.Trade
 		}
 		updateReq.EntryPrice = &response.Price
 
+		// Record the actual filled volume, which can be less than the
+		// requested volume on a partial fill - TP sizing downstream (see
+		// createTPOrdersIfFilled) must scale off this, not the request.
+		if response.Volume > 0 {
+			updateReq.Volume = &response.Volume
+		}
+
 		if response.Commission != 0 {
 			updateReq.Commission = &response.Commission
 		}
@@ -389,14 +1019,22 @@ func (p *Processor) executeTPTrade(ctx context.Context, trade *database.Trade) e
 		return nil
 	}
 
+	// Snap the TP price/volume onto the symbol's tick grid before it ever
+	// reaches ModifyPosition - the same normalization executeEntryTrade and
+	// the DCA leg loop apply before SendTrade.
+	normTakeProfit, normVolume, err := p.catalog.Normalize(ctx, trade.Symbol, *trade.EntryPrice, trade.Volume)
+	if err != nil {
+		return fmt.Errorf("failed to normalize TP trade for %s: %w", trade.Symbol, err)
+	}
+
 	// Create position-based TP order using MT5 PositionModify to set TP level
 	mt5Req := &mt5.PositionModifyRequest{
 		PositionTicket: *parentTrade.MT5Ticket,
 		Symbol:         trade.Symbol,
-		TakeProfit:     *trade.EntryPrice, // TP price level
-		StopLoss:       trade.StopLoss,    // Keep existing SL if any
-		PartialVolume:  trade.Volume,      // Volume to close at this level
-		TPType:         trade.TradeType,   // "tp1" or "tp2"
+		TakeProfit:     normTakeProfit, // TP price level
+		StopLoss:       trade.StopLoss, // Keep existing SL if any
+		PartialVolume:  normVolume,     // Volume to close at this level
+		TPType:         trade.TradeType, // "tp1" or "tp2"
 	}
 
 	log.Printf("Setting TP level for position %d: Symbol=%s, TP=%.5f, Volume=%.2f, Type=%s",
@@ -452,39 +1090,103 @@ func (p *Processor) executeTPTrade(ctx context.Context, trade *database.Trade) e
 // handleCloseSignal handles close signals by closing all open positions for a symbol
 func (p *Processor) handleCloseSignal(ctx context.Context, signal *database.Signal, tvWebhook *database.TradingViewWebhook) error {
 	log.Printf("Processing close signal for %s", signal.Symbol)
+	_, err := p.closeOpenTradesForSymbol(ctx, signal.Symbol, "")
+	return err
+}
+
+// applyReverseSignalAction enforces the configured ReverseSignalAction
+// when signal opposes the direction of symbol's existing filled trades.
+// It returns the trade ID to hedge (non-nil only in "hedge" mode) and the
+// volume that should actually be traded, which may be reduced by
+// "reduce_only" or zeroed out by "ignore" to signal the caller should
+// drop the signal entirely.
+func (p *Processor) applyReverseSignalAction(ctx context.Context, signal *database.Signal, volume float64) (*int, float64, error) {
+	opposite := getOppositeDirection(signal.SignalType)
 
-	// Get all open trades for this symbol
 	openTrades, err := p.db.GetOpenTrades(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get open trades: %w", err)
+		return nil, volume, fmt.Errorf("failed to get open trades: %w", err)
+	}
+
+	var opposingTrades []*database.Trade
+	var netOpenVolume float64
+	for _, trade := range openTrades {
+		if trade.Symbol == signal.Symbol && trade.Status == "filled" && trade.Direction == opposite {
+			opposingTrades = append(opposingTrades, trade)
+			netOpenVolume += trade.Volume
+		}
+	}
+
+	if len(opposingTrades) == 0 {
+		return nil, volume, nil
+	}
+
+	action := p.config.Risk.ReverseSignalAction
+	p.auditLog(ctx, "info", "reverse signal detected against open position", "signals", map[string]interface{}{
+		"signal_id": signal.ID, "symbol": signal.Symbol, "direction": signal.SignalType,
+		"opposing_trades": len(opposingTrades), "net_open_volume": netOpenVolume, "action": action,
+	})
+
+	switch action {
+	case "hedge":
+		// Leave the existing position(s) open and reference the first as
+		// the trade this new one hedges.
+		return &opposingTrades[0].ID, volume, nil
+	case "reduce_only":
+		if volume > netOpenVolume {
+			volume = netOpenVolume
+		}
+		return nil, volume, nil
+	case "ignore":
+		log.Printf("Signal %d: dropping reverse signal for %s (ReverseSignalAction=ignore)", signal.ID, signal.Symbol)
+		return nil, 0, nil
+	default: // "close"
+		if _, err := p.closeOpenTradesForSymbol(ctx, signal.Symbol, opposite); err != nil {
+			return nil, volume, fmt.Errorf("failed to close opposing trades: %w", err)
+		}
+		return nil, volume, nil
+	}
+}
+
+// closeOpenTradesForSymbol closes every filled open trade for symbol via
+// MT5, optionally restricted to a single direction ("buy"/"sell"; empty
+// closes both). It's shared by explicit close signals (handleCloseSignal)
+// and the "close" ReverseSignalAction, and returns the number of trades
+// it found open (closure failures are logged, not returned, matching the
+// existing best-effort close behavior).
+func (p *Processor) closeOpenTradesForSymbol(ctx context.Context, symbol, direction string) (int, error) {
+	openTrades, err := p.db.GetOpenTrades(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get open trades: %w", err)
 	}
 
 	var tradesToClose []*database.Trade
 	for _, trade := range openTrades {
-		if trade.Symbol == signal.Symbol && trade.Status == "filled" {
-			tradesToClose = append(tradesToClose, trade)
+		if trade.Symbol != symbol || trade.Status != "filled" {
+			continue
 		}
+		if direction != "" && trade.Direction != direction {
+			continue
+		}
+		tradesToClose = append(tradesToClose, trade)
 	}
 
 	if len(tradesToClose) == 0 {
-		log.Printf("No open trades found for %s", signal.Symbol)
-		return nil
+		log.Printf("No open trades found for %s", symbol)
+		return 0, nil
 	}
 
-	log.Printf("Closing %d open trades for %s", len(tradesToClose), signal.Symbol)
+	log.Printf("Closing %d open trades for %s", len(tradesToClose), symbol)
 
-	// Close each trade via MT5
 	for _, trade := range tradesToClose {
 		if err := p.closeTradeInMT5(ctx, trade); err != nil {
 			log.Printf("Failed to close trade %d: %v", trade.ID, err)
 			continue
 		}
-
-		// Update trade status to closed
 		p.updateTradeStatus(ctx, trade.ID, "closed", nil)
 	}
 
-	return nil
+	return len(tradesToClose), nil
 }
 
 // closeTradeInMT5 closes a specific trade in MT5
@@ -546,6 +1248,11 @@ func (p *Processor) syncPositionsFromMT5(ctx context.Context) error {
 
 		// Check if this is a position (executed trade)
 		if pos, exists := mt5Tickets[*trade.MT5Ticket]; exists {
+			// A partial TP close has happened since we last saw this
+			// entry trade if the live position volume has shrunk below
+			// what we last recorded for it.
+			partiallyClosed := trade.TradeType == "entry" && pos.Volume < trade.Volume
+
 			// Position still exists in MT5, update current data
 			updateReq := &database.UpdateTradeStatusRequest{
 				Status:       "filled", // Ensure status is set
@@ -554,10 +1261,25 @@ func (p *Processor) syncPositionsFromMT5(ctx context.Context) error {
 				Commission:   &pos.Commission,
 				Swap:         &pos.Swap,
 			}
+			if partiallyClosed {
+				updateReq.Volume = &pos.Volume
+			}
 
 			if err := p.db.UpdateTradeStatus(ctx, trade.ID, updateReq); err != nil {
 				log.Printf("Failed to update trade %d: %v", trade.ID, err)
 			}
+
+			if trade.TradeType == "entry" {
+				p.createTPOrdersIfFilled(ctx, trade, pos.Volume)
+
+				if partiallyClosed {
+					p.reduceHedgeLeg(ctx, trade, trade.Volume-pos.Volume, trade.Volume)
+
+					if p.config.Risk.TP1RunnerBreakeven {
+						p.shiftToBreakeven(ctx, trade)
+					}
+				}
+			}
 		} else if order, exists := mt5OrderTickets[*trade.MT5Ticket]; exists {
 			// This is a pending order (limit order not yet executed)
 			// Keep status as "pending" and update price if needed
@@ -582,6 +1304,20 @@ func (p *Processor) syncPositionsFromMT5(ctx context.Context) error {
 				// Position was closed
 				log.Printf("Trade %d (MT5 ticket %d) position no longer exists in MT5, marking as closed", trade.ID, *trade.MT5Ticket)
 				p.updateTradeStatus(ctx, trade.ID, "closed", nil)
+
+				if err := p.riskGuard.RecordClosedTrade(ctx, trade.ProfitLoss, p.cancelPendingTPOrders); err != nil {
+					log.Printf("Warning: Failed to record closed trade %d in risk guard: %v", trade.ID, err)
+				}
+				if err := p.volGuard.RecordClosedFees(ctx, trade.Symbol, trade.Commission+trade.Swap); err != nil {
+					log.Printf("Warning: Failed to record closed trade %d fees in budget guard: %v", trade.ID, err)
+				}
+
+				if trade.TradeType == "entry" {
+					p.closeHedgeLeg(ctx, trade)
+				}
+				if trade.TradeType == "dca_leg" {
+					p.cancelSiblingDCALegs(ctx, trade)
+				}
 			} else if trade.Status == "pending" {
 				// Pending order was removed/cancelled
 				log.Printf("Trade %d (MT5 ticket %d) pending order no longer exists in MT5, marking as cancelled", trade.ID, *trade.MT5Ticket)
@@ -593,6 +1329,38 @@ func (p *Processor) syncPositionsFromMT5(ctx context.Context) error {
 	return nil
 }
 
+// cancelPendingTPOrders cancels every still-pending TP order in MT5, for use
+// as the RiskGuard's cancelPending callback once it trips. Failures are
+// logged and skipped rather than aborting the sweep, matching
+// closeOpenTradesForSymbol's best-effort style.
+func (p *Processor) cancelPendingTPOrders(ctx context.Context) int {
+	openTrades, err := p.db.GetOpenTrades(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get open trades while cancelling pending TP orders: %v", err)
+		return 0
+	}
+
+	cancelled := 0
+	for _, trade := range openTrades {
+		if trade.Status != "pending" || trade.MT5Ticket == nil {
+			continue
+		}
+		if trade.TradeType != "tp1" && trade.TradeType != "tp2" {
+			continue
+		}
+
+		if err := p.mt5Client.CancelOrder(ctx, *trade.MT5Ticket); err != nil {
+			log.Printf("Warning: Failed to cancel pending TP order %d (MT5 ticket %d): %v", trade.ID, *trade.MT5Ticket, err)
+			continue
+		}
+
+		p.updateTradeStatus(ctx, trade.ID, "cancelled", nil)
+		cancelled++
+	}
+
+	return cancelled
+}
+
 // calculatePositionSize calculates the appropriate position size based on risk management
 func (p *Processor) calculatePositionSize(symbol string, requestedVolume float64) float64 {
 	// Use requested volume if provided and within limits
@@ -604,22 +1372,43 @@ func (p *Processor) calculatePositionSize(symbol string, requestedVolume float64
 	return 0.10
 }
 
-// validateRiskParameters validates trade parameters against risk management rules
-func (p *Processor) validateRiskParameters(ctx context.Context, tradeReq *database.CreateTradeRequest) error {
-	// Check position size
-	if tradeReq.Volume > p.config.Risk.MaxPositionSize {
+// projectedNotional estimates the dollar notional (price * lots) an entry
+// of volume lots would add, for the VolumeGuard. It's zero when signal
+// carries no price, which means an unpriced signal can never trip the
+// volume guard on its own - only its accumulated fees can.
+func (p *Processor) projectedNotional(signal *database.Signal, volume float64) float64 {
+	if signal.Price == nil {
+		return 0
+	}
+	return *signal.Price * volume
+}
+
+// EvaluateRiskDecision applies the position-size and open-position-count
+// rules shared by every risk check path. It is pure (no I/O) so it can be
+// exercised directly by the conformance test harness without a live MT5
+// bridge or database.
+func EvaluateRiskDecision(risk config.RiskConfig, volume float64, openPositions int) error {
+	if volume > risk.MaxPositionSize {
 		return fmt.Errorf("position size %.2f exceeds maximum allowed %.2f",
-			tradeReq.Volume, p.config.Risk.MaxPositionSize)
+			volume, risk.MaxPositionSize)
 	}
 
-	// Check number of open positions
+	if openPositions >= risk.MaxOpenPositions {
+		return fmt.Errorf("maximum open positions reached (%d)", risk.MaxOpenPositions)
+	}
+
+	return nil
+}
+
+// validateRiskParameters validates trade parameters against risk management rules
+func (p *Processor) validateRiskParameters(ctx context.Context, tradeReq *database.CreateTradeRequest) error {
 	openTrades, err := p.db.GetOpenTrades(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get open trades for risk check: %w", err)
 	}
 
-	if len(openTrades) >= p.config.Risk.MaxOpenPositions {
-		return fmt.Errorf("maximum open positions reached (%d)", p.config.Risk.MaxOpenPositions)
+	if err := EvaluateRiskDecision(p.config.Risk, tradeReq.Volume, len(openTrades)); err != nil {
+		return err
 	}
 
 	// TODO: Add more risk checks:
@@ -633,10 +1422,11 @@ func (p *Processor) validateRiskParameters(ctx context.Context, tradeReq *databa
 
 // validateRiskParametersFromSignal validates trade parameters against risk management rules
 func (p *Processor) validateRiskParametersFromSignal(ctx context.Context, signal *database.Signal, volume float64) error {
-	// Check position size
-	if volume > p.config.Risk.MaxPositionSize {
-		return fmt.Errorf("position size %.2f exceeds maximum allowed %.2f",
-			volume, p.config.Risk.MaxPositionSize)
+	// Daily loss / drawdown / losing-streak kill-switch, checked before
+	// any other risk rule - once tripped, no new signal should create a
+	// trade regardless of position count or size.
+	if err := p.riskGuard.Check(ctx); err != nil {
+		return err
 	}
 
 	// Check number of open positions using actual MT5 positions
@@ -649,8 +1439,8 @@ func (p *Processor) validateRiskParametersFromSignal(ctx context.Context, signal
 			return p.validateRiskParametersFromDatabase(ctx, volume)
 		}
 
-		if positionCount >= p.config.Risk.MaxOpenPositions {
-			return fmt.Errorf("maximum open positions reached (%d)", p.config.Risk.MaxOpenPositions)
+		if err := EvaluateRiskDecision(p.config.Risk, volume, positionCount); err != nil {
+			return err
 		}
 
 		log.Printf("Risk check passed: %d/%d positions open", positionCount, p.config.Risk.MaxOpenPositions)
@@ -660,55 +1450,78 @@ func (p *Processor) validateRiskParametersFromSignal(ctx context.Context, signal
 		return p.validateRiskParametersFromDatabase(ctx, volume)
 	}
 
-	// TODO: Add more risk checks:
-	// - Daily loss limit
-	// - Correlation checks
-	// - Account balance checks
-	// - Symbol-specific limits
-
 	return nil
 }
 
 // validateRiskParametersFromDatabase is a fallback method using database records
 func (p *Processor) validateRiskParametersFromDatabase(ctx context.Context, volume float64) error {
-	// Check position size
-	if volume > p.config.Risk.MaxPositionSize {
-		return fmt.Errorf("position size %.2f exceeds maximum allowed %.2f",
-			volume, p.config.Risk.MaxPositionSize)
-	}
-
-	// Check number of open positions from database
 	openTrades, err := p.db.GetOpenTrades(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get open trades for risk check: %w", err)
 	}
 
-	if len(openTrades) >= p.config.Risk.MaxOpenPositions {
-		return fmt.Errorf("maximum open positions reached (%d)", p.config.Risk.MaxOpenPositions)
-	}
-
-	return nil
+	return EvaluateRiskDecision(p.config.Risk, volume, len(openTrades))
 }
 
-// ProcessWebhook processes a webhook payload and creates a signal
-func (p *Processor) ProcessWebhook(ctx context.Context, webhookData []byte, source string) (*database.Signal, error) {
-	// Parse webhook based on source
-	var createReq *database.CreateSignalRequest
-	var err error
-
-	switch source {
-	case "tradingview":
-		createReq, err = p.parseTradingViewWebhook(webhookData)
-	default:
+// ProcessWebhook processes a webhook payload and creates a signal. The
+// source name is used to look up a registered SignalParser (see
+// RegisterParser); headers are passed through for parsers that need them
+// (e.g. per-source HMAC verification upstream, or header-carried metadata).
+func (p *Processor) ProcessWebhook(ctx context.Context, headers http.Header, webhookData []byte, source string) (*database.Signal, error) {
+	parser, ok := parserFor(source)
+	if !ok {
 		return nil, fmt.Errorf("unsupported webhook source: %s", source)
 	}
 
+	createReq, err := parser.Parse(ctx, headers, webhookData)
 	if err != nil {
+		p.auditLog(ctx, "error", "failed to parse webhook", "signals", map[string]interface{}{"source": source, "error": err.Error()})
 		return nil, fmt.Errorf("failed to parse webhook: %w", err)
 	}
+	if createReq.Source == "" {
+		createReq.Source = source
+	}
+	p.auditLog(ctx, "info", "webhook parsed", "signals", map[string]interface{}{"source": source, "symbol": createReq.Symbol, "signal_type": createReq.SignalType})
+
+	// Coarse, synchronous budget pre-check so a breached budget produces an
+	// immediate 429 instead of the webhook appearing accepted and then
+	// silently failing once picked up by the async signalProcessingLoop.
+	// The real sized volume isn't known until calculatePositionSize runs
+	// there, so this uses Risk.MaxPositionSize as a conservative worst-case
+	// estimate; processTradingViewSignal/processExchangeSignal re-check
+	// against the actual sized volume before creating the entry trade.
+	if createReq.SignalType != "close" {
+		notional := 0.0
+		if createReq.Price.Valid {
+			price, _ := createReq.Price.Decimal.Float64()
+			notional = price * p.config.Risk.MaxPositionSize
+		}
+		if err := p.volGuard.Check(ctx, createReq.Symbol, notional); err != nil {
+			p.auditLog(ctx, "error", "budget check rejected webhook", "signals", map[string]interface{}{"source": source, "symbol": createReq.Symbol, "error": err.Error()})
+			return nil, err
+		}
+	}
+
+	// Embed the correlation ID in the stored payload so it survives into
+	// the async signalProcessingLoop, which runs outside this request's
+	// context (see processTradingViewSignal).
+	if id := logging.CorrelationID(ctx); id != "" {
+		createReq.Payload = embedCorrelationID(createReq.Payload, id)
+	}
+
+	contentHash := idempotencyHash(createReq, headers.Get("X-Idempotency-Key"))
+	if existingID, err := p.db.FindIdempotentSignal(ctx, contentHash); err != nil {
+		log.Printf("Warning: idempotency lookup failed, proceeding without dedup: %v", err)
+	} else if existingID != nil {
+		log.Printf("Duplicate webhook detected (hash %s), returning original signal %d", contentHash, *existingID)
+		return &database.Signal{ID: *existingID, Source: createReq.Source, Symbol: createReq.Symbol, SignalType: createReq.SignalType}, nil
+	}
 
-	// Create signal in database
-	signal, err := p.db.CreateSignal(ctx, createReq)
+	// Create signal and its idempotency record atomically, so two
+	// concurrent identical webhooks that both miss the FindIdempotentSignal
+	// check above still can't each create their own signal (see
+	// CreateSignalIdempotent).
+	signal, err := p.db.CreateSignalIdempotent(ctx, createReq, contentHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signal: %w", err)
 	}
@@ -717,14 +1530,82 @@ func (p *Processor) ProcessWebhook(ctx context.Context, webhookData []byte, sour
 	return signal, nil
 }
 
-// parseTradingViewWebhook parses a TradingView webhook payload
-func (p *Processor) parseTradingViewWebhook(data []byte) (*database.CreateSignalRequest, error) {
+// embedCorrelationID stamps id onto a JSON payload as "_correlation_id" so
+// it round-trips through storage and back out via
+// database.TradingViewWebhook.CorrelationID. Non-JSON or unmarshalable
+// payloads are returned unchanged.
+func embedCorrelationID(payload json.RawMessage, id string) json.RawMessage {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return payload
+	}
+	raw["_correlation_id"] = id
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return payload
+	}
+	return data
+}
+
+// embedConsensus stamps the aggregator's provenance - which sources
+// voted, their weights, the final score - onto a signal's persisted
+// payload for later analysis. Falls back to the original payload if it
+// isn't a JSON object.
+func embedConsensus(payload json.RawMessage, consensus Consensus) json.RawMessage {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return payload
+	}
+	raw["_aggregator_consensus"] = consensus
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return payload
+	}
+	return data
+}
+
+// idempotencyHash fingerprints a normalized signal so retried alerts
+// (or duplicates relayed from another source) can be detected without
+// needing to compare raw payloads.
+func idempotencyHash(req *database.CreateSignalRequest, idempotencyKey string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s|%s|%s",
+		req.Source, req.Symbol, req.SignalType, decimalString(req.Price), req.Timestamp, idempotencyKey)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyPurgeLoop periodically removes idempotency records that have
+// aged out of the replay-protection window.
+func (p *Processor) idempotencyPurgeLoop(ctx context.Context) {
+	window := time.Duration(p.config.Server.IdempotencyWindowSeconds) * time.Second
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.db.PurgeExpiredIdempotency(ctx, window); err != nil {
+				log.Printf("Error purging expired idempotency records: %v", err)
+			}
+		}
+	}
+}
+
+// parseTradingViewWebhookData parses a TradingView webhook payload. If
+// maxAge is non-zero, signals whose embedded timestamp is older than maxAge
+// are rejected as likely replays. The check applies to JSON payloads only:
+// a body that falls back to parseSimpleFormatData is returned without it.
+func parseTradingViewWebhookData(data []byte, maxAge time.Duration) (*database.CreateSignalRequest, error) {
 	// First try to parse as JSON
 	var webhook database.TradingViewWebhook
 	if err := json.Unmarshal(data, &webhook); err != nil {
 		// If JSON parsing fails, try to parse as simple pipe-delimited format
 		// Format: ticker|action|entry|stop_loss|tp1|tp2|volume|timestamp
-		return p.parseSimpleFormat(string(data))
+		return parseSimpleFormatData(string(data))
 	}
 
 	// Continue with existing JSON parsing logic
@@ -743,7 +1624,15 @@ func (p *Processor) parseTradingViewWebhook(data []byte) (*database.CreateSignal
 	}
 
 	// Parse timestamp (flexible format)
-	timestampStr := p.parseTimestamp(webhook.Timestamp)
+	timestampStr := parseTimestampValue(webhook.Timestamp)
+
+	if maxAge > 0 {
+		if parsed, err := time.Parse(time.RFC3339, timestampStr); err == nil {
+			if age := time.Since(parsed); age > maxAge {
+				return nil, fmt.Errorf("signal timestamp %s is older than max age %s (age %s), rejecting as a possible replay", timestampStr, maxAge, age)
+			}
+		}
+	}
 
 	// Clean up the payload to avoid large timestamp values causing DB overflow
 	cleanedPayload := data
@@ -761,33 +1650,42 @@ func (p *Processor) parseTradingViewWebhook(data []byte) (*database.CreateSignal
 		Source:     "tradingview",
 		Symbol:     webhook.Ticker,
 		SignalType: signalType,
+		Timestamp:  timestampStr,
 		Payload:    cleanedPayload, // Store the cleaned webhook data
 	}
 
-	// Helper function to validate and set price fields
-	validatePrice := func(price *float64, fieldName string) (*float64, error) {
-		if price == nil || *price <= 0 {
-			return nil, nil
+	// Helper function to validate and set price fields. price arrives as a
+	// json.Number - the webhook JSON's numeric literal, preserved verbatim
+	// by the decoder - and is parsed directly via decimal.NewFromString so
+	// the DECIMAL(20,8) bounds check and rounding below operate on the
+	// exact value the caller sent instead of one already rounded by a
+	// lossy JSON-number-to-float64 unmarshal.
+	validatePrice := func(price json.Number, fieldName string) (decimal.NullDecimal, error) {
+		if price == "" {
+			return decimal.NullDecimal{}, nil
+		}
+		d, err := decimal.NewFromString(string(price))
+		if err != nil {
+			return decimal.NullDecimal{}, fmt.Errorf("%s is not a valid number: %q", fieldName, price)
 		}
-		// Validate price is within reasonable range for DECIMAL(20,8) - max 12 digits before decimal, 8 after
-		// Maximum safe value is 999999999999.99999999
-		if *price > 999999999999.99999999 {
-			return nil, fmt.Errorf("%s value too large: %.8f (max allowed: 999999999999.99999999)", fieldName, *price)
+		if !d.IsPositive() {
+			return decimal.NullDecimal{}, nil
 		}
-		// Round to 8 decimal places to match database precision
-		rounded := float64(int(*price*100000000+0.5)) / 100000000
-		return &rounded, nil
+		if d.GreaterThan(maxSignalPrice) {
+			return decimal.NullDecimal{}, fmt.Errorf("%s value too large: %s (max allowed: %s)", fieldName, d.String(), maxSignalPrice.String())
+		}
+		return decimal.NewNullDecimal(d.Round(8)), nil
 	}
 
 	// Add optional price fields with validation
 	var err error
 
 	// Use Entry field if available, otherwise fall back to Price field
-	if webhook.Entry != nil {
+	if webhook.Entry != "" {
 		if req.Price, err = validatePrice(webhook.Entry, "entry"); err != nil {
 			return nil, err
 		}
-	} else if webhook.Price != nil {
+	} else if webhook.Price != "" {
 		if req.Price, err = validatePrice(webhook.Price, "price"); err != nil {
 			return nil, err
 		}
@@ -810,22 +1708,22 @@ func (p *Processor) parseTradingViewWebhook(data []byte) (*database.CreateSignal
 	}
 
 	// Validate TP ordering based on signal direction
-	if req.TP1 != nil && req.TP2 != nil {
-		if signalType == "buy" && *req.TP2 <= *req.TP1 {
-			return nil, fmt.Errorf("for buy signals, TP2 (%.5f) must be greater than TP1 (%.5f)", *req.TP2, *req.TP1)
+	if req.TP1.Valid && req.TP2.Valid {
+		if signalType == "buy" && req.TP2.Decimal.LessThanOrEqual(req.TP1.Decimal) {
+			return nil, fmt.Errorf("for buy signals, TP2 (%s) must be greater than TP1 (%s)", req.TP2.Decimal.String(), req.TP1.Decimal.String())
 		}
-		if signalType == "sell" && *req.TP2 >= *req.TP1 {
-			return nil, fmt.Errorf("for sell signals, TP2 (%.5f) must be less than TP1 (%.5f)", *req.TP2, *req.TP1)
+		if signalType == "sell" && req.TP2.Decimal.GreaterThanOrEqual(req.TP1.Decimal) {
+			return nil, fmt.Errorf("for sell signals, TP2 (%s) must be less than TP1 (%s)", req.TP2.Decimal.String(), req.TP1.Decimal.String())
 		}
 	}
 
 	// Log the parsed webhook for debugging
-	log.Printf("Parsed webhook: Symbol=%s, Action=%s, Entry=%.5f, SL=%.5f, TP1=%.5f, TP2=%.5f, Timestamp=%s",
+	log.Printf("Parsed webhook: Symbol=%s, Action=%s, Entry=%s, SL=%s, TP1=%s, TP2=%s, Timestamp=%s",
 		req.Symbol, req.SignalType,
-		safeFloatValue(req.Price),
-		safeFloatValue(req.StopLoss),
-		safeFloatValue(req.TP1),
-		safeFloatValue(req.TP2),
+		decimalString(req.Price),
+		decimalString(req.StopLoss),
+		decimalString(req.TP1),
+		decimalString(req.TP2),
 		timestampStr)
 
 	// Debug log the actual values being sent to database
@@ -835,8 +1733,8 @@ func (p *Processor) parseTradingViewWebhook(data []byte) (*database.CreateSignal
 	return req, nil
 }
 
-// parseTimestamp handles flexible timestamp formats (number or string)
-func (p *Processor) parseTimestamp(timestampRaw json.RawMessage) string {
+// parseTimestampValue handles flexible timestamp formats (number or string)
+func parseTimestampValue(timestampRaw json.RawMessage) string {
 	if len(timestampRaw) == 0 {
 		return time.Now().Format(time.RFC3339)
 	}
@@ -844,25 +1742,22 @@ func (p *Processor) parseTimestamp(timestampRaw json.RawMessage) string {
 	// Try to parse as string first (quoted)
 	var timestampStr string
 	if err := json.Unmarshal(timestampRaw, &timestampStr); err == nil {
+		if decoded, ok := decodeTimestamp(timestampStr); ok {
+			return decoded
+		}
+		// Not a format timestampDecoders recognizes (RFC3339 included) -
+		// pass it through as-is, unvalidated.
 		return timestampStr
 	}
 
-	// Try to parse as number (Unix timestamp)
+	// Try to parse as number (Unix timestamp, any of seconds/millis/nanos)
 	var timestampNum int64
 	if err := json.Unmarshal(timestampRaw, &timestampNum); err == nil {
-		// Handle both seconds and milliseconds timestamps
-		if timestampNum > 1e12 {
-			// If timestamp is larger than 1e12, it's likely in milliseconds
-			timestampNum = timestampNum / 1000
-		}
-
-		// Validate timestamp is within reasonable range (year 1970-2100)
-		if timestampNum < 0 || timestampNum > 4102444800 { // Jan 1, 2100
-			log.Printf("Warning: Invalid timestamp %d, using current time", timestampNum)
-			return time.Now().Format(time.RFC3339)
+		if decoded, ok := decodeTimestamp(strconv.FormatInt(timestampNum, 10)); ok {
+			return decoded
 		}
-
-		return time.Unix(timestampNum, 0).Format(time.RFC3339)
+		log.Printf("Warning: Invalid timestamp %d, using current time", timestampNum)
+		return time.Now().Format(time.RFC3339)
 	}
 
 	// Fallback to current time if parsing fails
@@ -870,16 +1765,17 @@ func (p *Processor) parseTimestamp(timestampRaw json.RawMessage) string {
 	return time.Now().Format(time.RFC3339)
 }
 
-// Helper function to safely get float value for logging
-func safeFloatValue(f *float64) float64 {
-	if f == nil {
-		return 0.0
+// decimalString renders a NullDecimal for logging/hashing, treating an
+// unset value the same as the old *float64 nil-to-zero convention.
+func decimalString(d decimal.NullDecimal) string {
+	if !d.Valid {
+		return "0"
 	}
-	return *f
+	return d.Decimal.String()
 }
 
-// parseSimpleFormat parses pipe-delimited format: ticker|action|entry|stop_loss|tp1|tp2|volume|timestamp
-func (p *Processor) parseSimpleFormat(data string) (*database.CreateSignalRequest, error) {
+// parseSimpleFormatData parses pipe-delimited format: ticker|action|entry|stop_loss|tp1|tp2|volume|timestamp
+func parseSimpleFormatData(data string) (*database.CreateSignalRequest, error) {
 	parts := strings.Split(strings.TrimSpace(data), "|")
 	if len(parts) != 8 {
 		return nil, fmt.Errorf("simple format requires 8 parts separated by |, got %d parts", len(parts))
@@ -905,50 +1801,53 @@ func (p *Processor) parseSimpleFormat(data string) (*database.CreateSignalReques
 		return nil, fmt.Errorf("invalid action: %s, must be buy/sell/close", action)
 	}
 
-	// Parse numeric values
-	parseFloat := func(s, field string) (*float64, error) {
+	// Parse numeric values directly into decimal.Decimal via
+	// decimal.NewFromString, rather than through float64, so 8 decimal
+	// places of precision survive intact on symbols like BTCUSDT.
+	parseDecimal := func(s, field string) (decimal.NullDecimal, error) {
 		if s == "" || s == "0" {
-			return nil, nil
+			return decimal.NullDecimal{}, nil
 		}
-		val, err := strconv.ParseFloat(s, 64)
+		val, err := decimal.NewFromString(s)
 		if err != nil {
-			return nil, fmt.Errorf("invalid %s: %s", field, s)
+			return decimal.NullDecimal{}, fmt.Errorf("invalid %s: %s", field, s)
+		}
+		if val.Sign() <= 0 {
+			return decimal.NullDecimal{}, nil
 		}
-		if val <= 0 {
-			return nil, nil
+		if val.GreaterThan(maxSignalPrice) {
+			return decimal.NullDecimal{}, fmt.Errorf("%s value too large: %s (max allowed: %s)", field, val.String(), maxSignalPrice.String())
 		}
-		// Round to 8 decimal places to match database precision
-		rounded := float64(int(val*100000000+0.5)) / 100000000
-		return &rounded, nil
+		return decimal.NewNullDecimal(val.Round(8)), nil
 	}
 
-	entry, err := parseFloat(entryStr, "entry")
+	entry, err := parseDecimal(entryStr, "entry")
 	if err != nil {
 		return nil, err
 	}
 
-	stopLoss, err := parseFloat(slStr, "stop_loss")
+	stopLoss, err := parseDecimal(slStr, "stop_loss")
 	if err != nil {
 		return nil, err
 	}
 
-	tp1, err := parseFloat(tp1Str, "tp1")
+	tp1, err := parseDecimal(tp1Str, "tp1")
 	if err != nil {
 		return nil, err
 	}
 
-	tp2, err := parseFloat(tp2Str, "tp2")
+	tp2, err := parseDecimal(tp2Str, "tp2")
 	if err != nil {
 		return nil, err
 	}
 
 	// Validate TP ordering based on signal direction
-	if tp1 != nil && tp2 != nil {
-		if action == "buy" && *tp2 <= *tp1 {
-			return nil, fmt.Errorf("for buy signals, TP2 (%.5f) must be greater than TP1 (%.5f)", *tp2, *tp1)
+	if tp1.Valid && tp2.Valid {
+		if action == "buy" && tp2.Decimal.LessThanOrEqual(tp1.Decimal) {
+			return nil, fmt.Errorf("for buy signals, TP2 (%s) must be greater than TP1 (%s)", tp2.Decimal.String(), tp1.Decimal.String())
 		}
-		if action == "sell" && *tp2 >= *tp1 {
-			return nil, fmt.Errorf("for sell signals, TP2 (%.5f) must be less than TP1 (%.5f)", *tp2, *tp1)
+		if action == "sell" && tp2.Decimal.GreaterThanOrEqual(tp1.Decimal) {
+			return nil, fmt.Errorf("for sell signals, TP2 (%s) must be less than TP1 (%s)", tp2.Decimal.String(), tp1.Decimal.String())
 		}
 	}
 
@@ -967,40 +1866,248 @@ func (p *Processor) parseSimpleFormat(data string) (*database.CreateSignalReques
 		Payload:    []byte(simplePayload),
 	}
 
-	log.Printf("Parsed simple format: Symbol=%s, Action=%s, Entry=%.5f, SL=%.5f, TP1=%.5f, TP2=%.5f",
+	log.Printf("Parsed simple format: Symbol=%s, Action=%s, Entry=%s, SL=%s, TP1=%s, TP2=%s",
+		req.Symbol, req.SignalType,
+		decimalString(req.Price),
+		decimalString(req.StopLoss),
+		decimalString(req.TP1),
+		decimalString(req.TP2))
+
+	return req, nil
+}
+
+// compactBinaryContentType is the Content-Type that routes a TradingView
+// webhook body to parseCompactBinarySignal instead of the JSON/pipe
+// formats.
+const compactBinaryContentType = "application/x-tradingsignal"
+
+// compactBinaryVersion is the only layout parseCompactBinarySignal
+// understands today; bump it and switch on it if the layout ever changes.
+const compactBinaryVersion = 1
+
+// compactBinaryPriceExponent scales the fixed-point price integers in the
+// compact binary format, matching the signals table's DECIMAL(20,8)
+// precision.
+const compactBinaryPriceExponent = 8
+
+// compactBinaryActions maps the format's single action byte to a
+// CreateSignalRequest.SignalType value.
+var compactBinaryActions = map[byte]string{0: "buy", 1: "sell", 2: "close"}
+
+// parseCompactBinarySignal decodes the compact, length-prefixed binary
+// signal format registered under Content-Type: application/x-tradingsignal,
+// for alerts forwarded through low-bandwidth relays or signing pipelines.
+// Prices are carried as sint64 fixed-point integers scaled by
+// compactBinaryPriceExponent rather than floats or decimal strings, so the
+// whole payload is an opaque, byte-stable blob that can be HMAC signed
+// the same way as any other webhook body (see
+// Server.verifyWebhookSignature, which checks HMAC-SHA256 only; Ed25519
+// signatures are not verified) without a JSON re-serialization step
+// risking a signature mismatch.
+//
+// This is a hand-rolled fixed layout rather than real protobuf: the repo
+// has no protobuf toolchain/dependency today, and these few scalar fields
+// don't need protobuf's schema evolution machinery.
+//
+// Layout (little-endian):
+//
+//	byte(version) byte(symbolLen) symbol[symbolLen] byte(action)
+//	int64(entry) int64(stopLoss) int64(tp1) int64(tp2) int64(unixSeconds)
+//
+// A price of zero or less means "not set", matching the sentinel
+// convention validatePrice and parseDecimal already use elsewhere in this
+// file.
+func parseCompactBinarySignal(data []byte, maxAge time.Duration) (*database.CreateSignalRequest, error) {
+	const fixedFieldsLen = 1 + 8*5 // action byte + 5 int64 fields
+	if len(data) < 2 {
+		return nil, fmt.Errorf("compact signal: payload too short")
+	}
+
+	version := data[0]
+	if version != compactBinaryVersion {
+		return nil, fmt.Errorf("compact signal: unsupported version %d", version)
+	}
+
+	symbolLen := int(data[1])
+	pos := 2
+	if len(data) < pos+symbolLen+fixedFieldsLen {
+		return nil, fmt.Errorf("compact signal: payload truncated")
+	}
+
+	symbol := strings.ToUpper(string(data[pos : pos+symbolLen]))
+	pos += symbolLen
+	if symbol == "" {
+		return nil, fmt.Errorf("compact signal: missing symbol")
+	}
+
+	actionByte := data[pos]
+	pos++
+	action, ok := compactBinaryActions[actionByte]
+	if !ok {
+		return nil, fmt.Errorf("compact signal: unknown action byte %d", actionByte)
+	}
+
+	readScaledPrice := func() decimal.NullDecimal {
+		raw := int64(binary.LittleEndian.Uint64(data[pos : pos+8]))
+		pos += 8
+		if raw <= 0 {
+			return decimal.NullDecimal{}
+		}
+		return decimal.NewNullDecimal(decimal.New(raw, -compactBinaryPriceExponent))
+	}
+
+	entry := readScaledPrice()
+	stopLoss := readScaledPrice()
+	tp1 := readScaledPrice()
+	tp2 := readScaledPrice()
+
+	unixSeconds := int64(binary.LittleEndian.Uint64(data[pos : pos+8]))
+	pos += 8
+	timestampStr := time.Unix(unixSeconds, 0).UTC().Format(time.RFC3339)
+
+	if maxAge > 0 {
+		if age := time.Since(time.Unix(unixSeconds, 0)); age > maxAge {
+			return nil, fmt.Errorf("signal timestamp %s is older than max age %s (age %s), rejecting as a possible replay", timestampStr, maxAge, age)
+		}
+	}
+
+	req := &database.CreateSignalRequest{
+		Source:     "tradingview",
+		Symbol:     symbol,
+		SignalType: action,
+		Price:      entry,
+		StopLoss:   stopLoss,
+		TP1:        tp1,
+		TP2:        tp2,
+		Timestamp:  timestampStr,
+		Payload:    data,
+	}
+
+	log.Printf("Parsed compact binary format: Symbol=%s, Action=%s, Entry=%s, SL=%s, TP1=%s, TP2=%s",
 		req.Symbol, req.SignalType,
-		safeFloatValue(req.Price),
-		safeFloatValue(req.StopLoss),
-		safeFloatValue(req.TP1),
-		safeFloatValue(req.TP2))
+		decimalString(req.Price),
+		decimalString(req.StopLoss),
+		decimalString(req.TP1),
+		decimalString(req.TP2))
 
 	return req, nil
 }
 
 // createTPTradeWithRetry creates a TP trade with retry logic to handle database connection issues
-func (p *Processor) createTPTradeWithRetry(ctx context.Context, signal *database.Signal, parentTradeID int, tpType string, tpPrice float64, volume float64) (*database.Trade, error) {
+func (p *Processor) createTPTradeWithRetry(ctx context.Context, entryTrade *database.Trade, tpType string, tpPrice float64, volume float64) (*database.Trade, error) {
+	// TP legs still place an order on the venue and accrue their own
+	// commission/swap, so they're budget-checked like any other trade
+	// even though they reduce rather than add to open exposure.
+	if err := p.volGuard.Check(ctx, entryTrade.Symbol, tpPrice*volume); err != nil {
+		p.auditLog(ctx, "error", "budget check rejected TP trade", "signals", map[string]interface{}{"entry_trade_id": entryTrade.ID, "tp_type": tpType, "error": err.Error()})
+		return nil, err
+	}
+
 	maxRetries := 3
 	var lastErr error
-	
+
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		if attempt > 0 {
 			// Wait a bit between retries
 			time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
 			log.Printf("Retrying TP trade creation, attempt %d/%d", attempt+1, maxRetries)
 		}
-		
-		trade, err := p.createTPTrade(ctx, signal, parentTradeID, tpType, tpPrice, volume)
+
+		trade, err := p.createTPTrade(ctx, entryTrade, tpType, tpPrice, volume)
 		if err == nil {
 			return trade, nil
 		}
-		
+
 		lastErr = err
 		log.Printf("Failed to create TP trade (attempt %d/%d): %v", attempt+1, maxRetries, err)
 	}
-	
+
 	return nil, fmt.Errorf("failed to create TP trade after %d attempts: %w", maxRetries, lastErr)
 }
 
+// createTPOrdersIfFilled places TP1/TP2 orders for entryTrade the first
+// time its MT5 position is confirmed open, sized off actualVolume (the
+// position's real filled volume, not the originally requested one) split
+// according to Risk.TP1VolumePct. Safe to call on every poll: it checks
+// for existing TP children first and is a no-op once they exist.
+func (p *Processor) createTPOrdersIfFilled(ctx context.Context, entryTrade *database.Trade, actualVolume float64) {
+	if entryTrade.TP1 == nil && entryTrade.TP2 == nil {
+		return
+	}
+
+	children, err := p.db.GetTradesByParent(ctx, entryTrade.ID)
+	if err != nil {
+		log.Printf("Failed to check existing TP orders for trade %d: %v", entryTrade.ID, err)
+		return
+	}
+	for _, child := range children {
+		if child.TradeType == "tp1" || child.TradeType == "tp2" {
+			return // already placed
+		}
+	}
+
+	log.Printf("Entry trade %d confirmed filled at %.2f lots, creating TP orders...", entryTrade.ID, actualVolume)
+
+	if entryTrade.TP1 != nil && *entryTrade.TP1 > 0 {
+		tp1Volume := actualVolume * p.config.Risk.TP1VolumePct
+		tp1Trade, err := p.createTPTradeWithRetry(ctx, entryTrade, "tp1", *entryTrade.TP1, tp1Volume)
+		if err != nil {
+			log.Printf("Failed to create TP1 trade: %v", err)
+		} else if err := p.executeTPTrade(ctx, tp1Trade); err != nil {
+			log.Printf("Failed to execute TP1 trade %d: %v", tp1Trade.ID, err)
+			p.updateTradeStatus(ctx, tp1Trade.ID, "rejected", nil)
+		} else {
+			log.Printf("TP1 trade %d successfully placed", tp1Trade.ID)
+		}
+	}
+
+	if entryTrade.TP2 != nil && *entryTrade.TP2 > 0 {
+		tp2Volume := actualVolume * (1 - p.config.Risk.TP1VolumePct)
+		tp2Trade, err := p.createTPTradeWithRetry(ctx, entryTrade, "tp2", *entryTrade.TP2, tp2Volume)
+		if err != nil {
+			log.Printf("Failed to create TP2 trade: %v", err)
+		} else if err := p.executeTPTrade(ctx, tp2Trade); err != nil {
+			log.Printf("Failed to execute TP2 trade %d: %v", tp2Trade.ID, err)
+			p.updateTradeStatus(ctx, tp2Trade.ID, "rejected", nil)
+		} else {
+			log.Printf("TP2 trade %d successfully placed", tp2Trade.ID)
+		}
+	}
+}
+
+// shiftToBreakeven moves entryTrade's stop-loss to its entry price once a
+// partial close has reduced the live position below its last known
+// volume - the standard TP1-hit runner-management technique. It updates
+// both MT5 and the database, and is a no-op once the SL already sits at
+// breakeven so it's safe to call on every poll.
+func (p *Processor) shiftToBreakeven(ctx context.Context, entryTrade *database.Trade) {
+	if entryTrade.EntryPrice == nil {
+		return
+	}
+	if entryTrade.StopLoss != nil && *entryTrade.StopLoss == *entryTrade.EntryPrice {
+		return // already at breakeven
+	}
+
+	breakeven := *entryTrade.EntryPrice
+	_, err := p.mt5Client.ModifyPosition(ctx, &mt5.PositionModifyRequest{
+		PositionTicket: *entryTrade.MT5Ticket,
+		Symbol:         entryTrade.Symbol,
+		StopLoss:       &breakeven,
+	})
+	if err != nil {
+		log.Printf("Failed to shift trade %d to breakeven: %v", entryTrade.ID, err)
+		return
+	}
+
+	updateReq := &database.UpdateTradeStatusRequest{Status: "filled", StopLoss: &breakeven}
+	if err := p.db.UpdateTradeStatus(ctx, entryTrade.ID, updateReq); err != nil {
+		log.Printf("Failed to persist breakeven stop for trade %d: %v", entryTrade.ID, err)
+		return
+	}
+
+	log.Printf("Trade %d runner shifted to breakeven at %.5f after TP1 fill", entryTrade.ID, breakeven)
+}
+
 // getTradeByID retrieves a trade by its ID
 func (p *Processor) getTradeByID(ctx context.Context, tradeID int) (*database.Trade, error) {
 	return p.db.GetTradeByID(ctx, tradeID)
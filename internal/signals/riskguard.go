@@ -0,0 +1,182 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"trading-system/internal/config"
+	"trading-system/internal/database"
+	"trading-system/internal/mt5"
+)
+
+// RiskGuard is a prop-firm-style kill-switch: it tracks today's realized
+// P/L, intraday peak equity, and consecutive losing trades, and refuses
+// new signals once any of Risk.MaxDailyLossPct,
+// Risk.MaxIntradayDrawdownPct, or Risk.MaxConsecutiveLosses is breached.
+// Its baseline is persisted via database.RiskGuardState so a process
+// restart mid-session resumes from where it left off instead of
+// re-stamping a fresh starting balance.
+type RiskGuard struct {
+	db  *database.DB
+	mt5 *mt5.Client
+	cfg config.RiskConfig
+
+	mu    sync.Mutex
+	state *database.RiskGuardState
+}
+
+// NewRiskGuard creates a RiskGuard. Its baseline is loaded lazily, on the
+// first Check or RecordClosedTrade call, so construction never blocks on
+// MT5 or the database.
+func NewRiskGuard(db *database.DB, mt5Client *mt5.Client, cfg config.RiskConfig) *RiskGuard {
+	return &RiskGuard{db: db, mt5: mt5Client, cfg: cfg}
+}
+
+// tradingDay truncates t to its calendar day in t's own location (local
+// time for time.Now()), returned as midnight UTC of that date, used as the
+// RiskGuardState key so the baseline resets once per day.
+func tradingDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// loadState returns today's baseline, querying MT5 for the starting
+// account balance the first time it's needed today. Callers must hold g.mu.
+func (g *RiskGuard) loadState(ctx context.Context) (*database.RiskGuardState, error) {
+	today := tradingDay(time.Now())
+	if g.state != nil && g.state.TradingDay.Equal(today) {
+		return g.state, nil
+	}
+
+	startingBalance := 0.0
+	if g.mt5.IsConnected(ctx) {
+		account, err := g.mt5.GetAccountInfo(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get MT5 account info for risk guard baseline: %w", err)
+		}
+		startingBalance = account.Balance
+	}
+
+	state, err := g.db.GetOrCreateRiskGuardState(ctx, today, startingBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	g.state = state
+	return state, nil
+}
+
+// Check returns an error naming which guard tripped, if any. Call it from
+// the risk validation path before a new signal is allowed to create a
+// trade.
+func (g *RiskGuard) Check(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, err := g.loadState(ctx)
+	if err != nil {
+		return err
+	}
+	if state.Tripped {
+		return fmt.Errorf("risk guard tripped: %s", state.TripReason)
+	}
+	return nil
+}
+
+// RecordClosedTrade folds a just-closed trade's realized P/L into today's
+// running totals, updates the intraday peak equity and consecutive-losses
+// streak, and trips the guard - cancelling every pending TP order via
+// cancelPending - if any configured threshold is now breached.
+func (g *RiskGuard) RecordClosedTrade(ctx context.Context, profitLoss float64, cancelPending func(context.Context) int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, err := g.loadState(ctx)
+	if err != nil {
+		return err
+	}
+	if state.Tripped {
+		return nil // already tripped; nothing further to accumulate
+	}
+
+	state.RealizedPnL += profitLoss
+
+	if profitLoss < 0 {
+		state.ConsecutiveLosses++
+	} else {
+		state.ConsecutiveLosses = 0
+	}
+
+	currentEquity := state.StartingBalance + state.RealizedPnL
+	if currentEquity > state.PeakEquity {
+		state.PeakEquity = currentEquity
+	}
+
+	reason := g.tripReason(state, currentEquity)
+	state.Tripped = reason != ""
+	state.TripReason = reason
+
+	if err := g.db.UpdateRiskGuardState(ctx, state.ID, &database.UpdateRiskGuardStateRequest{
+		PeakEquity:        state.PeakEquity,
+		RealizedPnL:       state.RealizedPnL,
+		ConsecutiveLosses: state.ConsecutiveLosses,
+		Tripped:           state.Tripped,
+		TripReason:        state.TripReason,
+	}); err != nil {
+		return fmt.Errorf("failed to persist risk guard state: %w", err)
+	}
+
+	if state.Tripped && cancelPending != nil {
+		cancelled := cancelPending(ctx)
+		state.TripReason = fmt.Sprintf("%s (cancelled %d pending TP orders)", reason, cancelled)
+	}
+
+	return nil
+}
+
+// tripReason evaluates every configured threshold against state and
+// currentEquity, returning a description of the first one breached, or ""
+// if none are.
+func (g *RiskGuard) tripReason(state *database.RiskGuardState, currentEquity float64) string {
+	if state.StartingBalance > 0 {
+		lossPct := -state.RealizedPnL / state.StartingBalance * 100
+		if lossPct >= g.cfg.MaxDailyLossPct {
+			return fmt.Sprintf("daily loss %.2f%% reached limit %.2f%%", lossPct, g.cfg.MaxDailyLossPct)
+		}
+	}
+
+	if state.PeakEquity > 0 {
+		drawdownPct := (state.PeakEquity - currentEquity) / state.PeakEquity * 100
+		if drawdownPct >= g.cfg.MaxIntradayDrawdownPct {
+			return fmt.Sprintf("intraday drawdown %.2f%% reached limit %.2f%%", drawdownPct, g.cfg.MaxIntradayDrawdownPct)
+		}
+	}
+
+	if state.ConsecutiveLosses >= g.cfg.MaxConsecutiveLosses {
+		return fmt.Sprintf("%d consecutive losing trades reached limit %d", state.ConsecutiveLosses, g.cfg.MaxConsecutiveLosses)
+	}
+
+	return ""
+}
+
+// Reset clears a tripped guard's trip flag and losing streak, for the
+// manual admin reset endpoint. It does not erase today's realized P/L or
+// peak equity - those remain the day's true history.
+func (g *RiskGuard) Reset(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, err := g.loadState(ctx)
+	if err != nil {
+		return err
+	}
+	if err := g.db.ResetRiskGuardState(ctx, state.ID); err != nil {
+		return err
+	}
+
+	state.Tripped = false
+	state.TripReason = ""
+	state.ConsecutiveLosses = 0
+	return nil
+}
@@ -0,0 +1,195 @@
+package signals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"trading-system/internal/config"
+	"trading-system/internal/database"
+)
+
+// BudgetExceededError is returned by VolumeGuard.Check when a signal would
+// breach the daily volume or per-symbol fee budget. server.handleWebhook
+// detects it via errors.As and responds 429 instead of 500, so TradingView
+// gets a clear "back off" signal rather than a generic server error.
+type BudgetExceededError struct {
+	Reason string
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("daily budget exceeded: %s", e.Reason)
+}
+
+// VolumeGuard tracks today's accumulated notional trade volume and
+// per-symbol broker fees, and refuses new entries once
+// Budget.DailyMaxVolume or Budget.DailyFeeBudgets[symbol] would be
+// breached. Its baseline is persisted via database.BudgetState so a
+// process restart mid-session resumes from today's running totals
+// instead of starting back at zero. Unlike RiskGuard, it has no manual
+// reset: the budget is scoped to a calendar day and clears itself once
+// tradingDay rolls over.
+type VolumeGuard struct {
+	db  *database.DB
+	cfg config.BudgetConfig
+
+	mu    sync.Mutex
+	state *database.BudgetState
+	fees  map[string]float64
+}
+
+// NewVolumeGuard creates a VolumeGuard. Its baseline is loaded lazily, on
+// the first Check, RecordEntryVolume, or RecordClosedFees call.
+func NewVolumeGuard(db *database.DB, cfg config.BudgetConfig) *VolumeGuard {
+	return &VolumeGuard{db: db, cfg: cfg}
+}
+
+// loadState returns today's baseline, decoding the persisted fee map the
+// first time it's needed today. Callers must hold g.mu.
+func (g *VolumeGuard) loadState(ctx context.Context) (*database.BudgetState, error) {
+	today := tradingDay(time.Now())
+	if g.state != nil && g.state.TradingDay.Equal(today) {
+		return g.state, nil
+	}
+
+	state, err := g.db.GetOrCreateBudgetState(ctx, today)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := map[string]float64{}
+	if len(state.AccumulatedFees) > 0 {
+		if err := json.Unmarshal(state.AccumulatedFees, &fees); err != nil {
+			return nil, fmt.Errorf("failed to decode budget state fees: %w", err)
+		}
+	}
+
+	g.state = state
+	g.fees = fees
+	return state, nil
+}
+
+// persist writes g.state/g.fees back to the database. Callers must hold g.mu.
+func (g *VolumeGuard) persist(ctx context.Context) error {
+	feesJSON, err := json.Marshal(g.fees)
+	if err != nil {
+		return fmt.Errorf("failed to encode budget state fees: %w", err)
+	}
+
+	if err := g.db.UpdateBudgetState(ctx, g.state.ID, &database.UpdateBudgetStateRequest{
+		AccumulatedVolume: g.state.AccumulatedVolume,
+		AccumulatedFees:   feesJSON,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Check rejects a new entry of projectedVolume notional on symbol if it
+// would push today's accumulated volume over Budget.DailyMaxVolume, or if
+// symbol's accumulated fees have already reached its configured budget. A
+// disabled guard (Budget.Enabled false) always passes.
+func (g *VolumeGuard) Check(ctx context.Context, symbol string, projectedVolume float64) error {
+	if !g.cfg.Enabled {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, err := g.loadState(ctx)
+	if err != nil {
+		return err
+	}
+
+	if g.cfg.DailyMaxVolume > 0 && state.AccumulatedVolume+projectedVolume > g.cfg.DailyMaxVolume {
+		return &BudgetExceededError{Reason: fmt.Sprintf(
+			"projected notional volume %.2f would push today's total to %.2f, over the %.2f daily max",
+			projectedVolume, state.AccumulatedVolume+projectedVolume, g.cfg.DailyMaxVolume,
+		)}
+	}
+
+	if feeBudget, ok := g.cfg.DailyFeeBudgets[symbol]; ok && feeBudget > 0 && g.fees[symbol] >= feeBudget {
+		return &BudgetExceededError{Reason: fmt.Sprintf(
+			"%s has accumulated %.2f in fees today, at or over its %.2f daily fee budget",
+			symbol, g.fees[symbol], feeBudget,
+		)}
+	}
+
+	return nil
+}
+
+// RecordEntryVolume folds a newly-opened entry trade's notional volume
+// into today's running total. Call it once the trade is confirmed
+// submitted, regardless of venue (MT5 or a router-resolved exchange).
+func (g *VolumeGuard) RecordEntryVolume(ctx context.Context, volume float64) error {
+	if !g.cfg.Enabled || volume <= 0 {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, err := g.loadState(ctx); err != nil {
+		return err
+	}
+
+	g.state.AccumulatedVolume += volume
+	return g.persist(ctx)
+}
+
+// RecordClosedFees folds a just-closed trade's broker fees (commission +
+// swap) into symbol's running total for today.
+func (g *VolumeGuard) RecordClosedFees(ctx context.Context, symbol string, fees float64) error {
+	if !g.cfg.Enabled || fees == 0 {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, err := g.loadState(ctx); err != nil {
+		return err
+	}
+
+	g.fees[symbol] += fees
+	return g.persist(ctx)
+}
+
+// BudgetStatus reports today's budget headroom, for the admin status
+// endpoint.
+type BudgetStatus struct {
+	TradingDay        string             `json:"trading_day"`
+	AccumulatedVolume float64            `json:"accumulated_volume"`
+	DailyMaxVolume    float64            `json:"daily_max_volume"`
+	AccumulatedFees   map[string]float64 `json:"accumulated_fees"`
+	DailyFeeBudgets   map[string]float64 `json:"daily_fee_budgets"`
+}
+
+// Status returns today's accumulated volume/fees alongside the configured
+// ceilings.
+func (g *VolumeGuard) Status(ctx context.Context) (*BudgetStatus, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, err := g.loadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := make(map[string]float64, len(g.fees))
+	for k, v := range g.fees {
+		fees[k] = v
+	}
+
+	return &BudgetStatus{
+		TradingDay:        state.TradingDay.Format("2006-01-02"),
+		AccumulatedVolume: state.AccumulatedVolume,
+		DailyMaxVolume:    g.cfg.DailyMaxVolume,
+		AccumulatedFees:   fees,
+		DailyFeeBudgets:   g.cfg.DailyFeeBudgets,
+	}, nil
+}
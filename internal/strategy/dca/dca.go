@@ -0,0 +1,188 @@
+// Package dca plans a dollar-cost-averaging ladder of entry legs for a
+// single TradingView signal, mirroring the dca2 strategy from bbgo: instead
+// of one market order, the signal's budget is split across MaxOrderCount
+// limit legs at progressively worse prices, sized to stay within
+// QuoteInvestment, sharing one take profit computed off the ladder's
+// volume-weighted average entry rather than any individual leg's price.
+package dca
+
+import (
+	"fmt"
+	"math"
+
+	"trading-system/internal/database"
+)
+
+// Config describes one DCA ladder, parsed from a database.DCAConfig
+// webhook field via ParseConfig.
+type Config struct {
+	MaxOrderCount int
+	// PriceDeviation is the fractional price gap between successive steps,
+	// e.g. 0.01 for 1%. Step i sits at entry*(1 - i*PriceDeviation) for a
+	// long, entry*(1 + i*PriceDeviation) for a short.
+	PriceDeviation float64
+	// VolumeScale is the per-step volume multiplier: in "geometric" mode
+	// step i's weight is VolumeScale^i, in "linear" mode it's
+	// 1 + i*(VolumeScale-1).
+	VolumeScale float64
+	// ScaleMode is "geometric" (default) or "linear".
+	ScaleMode string
+	// TakeProfitRatio is the fractional return target off the ladder's
+	// volume-weighted average entry.
+	TakeProfitRatio float64
+	// QuoteInvestment is the total quote-currency budget spread across
+	// every leg: sum(volume_i * price_i) is scaled to not exceed it.
+	QuoteInvestment float64
+	// MinVolume and VolumeStep are the symbol's broker-side lot
+	// constraints; a step whose volume rounds below MinVolume is dropped.
+	MinVolume  float64
+	VolumeStep float64
+}
+
+// ParseConfig validates and converts a webhook-supplied DCA request.
+func ParseConfig(req *database.DCAConfig) (*Config, error) {
+	if req.MaxOrderCount <= 0 {
+		return nil, fmt.Errorf("dca.max_order_count must be positive, got %d", req.MaxOrderCount)
+	}
+	if req.PriceDeviation <= 0 {
+		return nil, fmt.Errorf("dca.price_deviation must be positive, got %f", req.PriceDeviation)
+	}
+	if req.VolumeScale <= 0 {
+		return nil, fmt.Errorf("dca.volume_scale must be positive, got %f", req.VolumeScale)
+	}
+	// A long's last step sits at entry*(1 - (MaxOrderCount-1)*PriceDeviation):
+	// once that deviation reaches 1.0, the step price is zero or negative,
+	// which is never a valid limit price.
+	if maxDeviation := float64(req.MaxOrderCount-1) * req.PriceDeviation; maxDeviation >= 1 {
+		return nil, fmt.Errorf("dca: (max_order_count-1)*price_deviation must be < 1, got %f", maxDeviation)
+	}
+	if req.TakeProfitRatio <= 0 {
+		return nil, fmt.Errorf("dca.take_profit_ratio must be positive, got %f", req.TakeProfitRatio)
+	}
+	if req.QuoteInvestment <= 0 {
+		return nil, fmt.Errorf("dca.quote_investment must be positive, got %f", req.QuoteInvestment)
+	}
+
+	mode := req.ScaleMode
+	if mode == "" {
+		mode = "geometric"
+	}
+	if mode != "geometric" && mode != "linear" {
+		return nil, fmt.Errorf("dca.scale_mode must be \"geometric\" or \"linear\", got %q", mode)
+	}
+
+	return &Config{
+		MaxOrderCount:   req.MaxOrderCount,
+		PriceDeviation:  req.PriceDeviation,
+		VolumeScale:     req.VolumeScale,
+		ScaleMode:       mode,
+		TakeProfitRatio: req.TakeProfitRatio,
+		QuoteInvestment: req.QuoteInvestment,
+		MinVolume:       req.MinVolume,
+		VolumeStep:      req.VolumeStep,
+	}, nil
+}
+
+// Plan lays out signal's DCA ladder as child CreateTradeRequests linked
+// back to it via ParentSignalID and tagged TradeType "dca_leg", ready for
+// database.DB.CreateTradesBatch. Steps that round below cfg.MinVolume are
+// dropped entirely rather than submitted at zero size. Every surviving leg
+// carries the same shared take profit (see weightedTakeProfit), since the
+// ladder is only meant to be closed once, as a whole, rather than leg by
+// leg.
+func Plan(signal *database.Signal, cfg Config) ([]*database.CreateTradeRequest, error) {
+	if signal.Price == nil {
+		return nil, fmt.Errorf("dca: signal %d has no entry price", signal.ID)
+	}
+	entry := *signal.Price
+	if entry <= 0 {
+		return nil, fmt.Errorf("dca: signal %d entry price must be positive, got %f", signal.ID, entry)
+	}
+	long := signal.SignalType == "buy"
+
+	prices := make([]float64, cfg.MaxOrderCount)
+	weights := make([]float64, cfg.MaxOrderCount)
+	var totalWeightedPrice float64
+	for i := 0; i < cfg.MaxOrderCount; i++ {
+		dev := float64(i) * cfg.PriceDeviation
+		if long {
+			prices[i] = entry * (1 - dev)
+		} else {
+			prices[i] = entry * (1 + dev)
+		}
+		if prices[i] <= 0 {
+			return nil, fmt.Errorf("dca: signal %d step %d priced at %f, must be positive", signal.ID, i, prices[i])
+		}
+
+		var weight float64
+		if cfg.ScaleMode == "linear" {
+			weight = 1 + float64(i)*(cfg.VolumeScale-1)
+		} else {
+			weight = math.Pow(cfg.VolumeScale, float64(i))
+		}
+		weights[i] = weight
+		totalWeightedPrice += weight * prices[i]
+	}
+	if totalWeightedPrice <= 0 {
+		return nil, fmt.Errorf("dca: signal %d produced a non-positive weighted price total", signal.ID)
+	}
+
+	// Scale every step's weight so sum(volume_i * price_i) == QuoteInvestment.
+	unit := cfg.QuoteInvestment / totalWeightedPrice
+
+	legs := make([]*database.CreateTradeRequest, 0, cfg.MaxOrderCount)
+	var weightedEntrySum, filledVolume float64
+	for i := 0; i < cfg.MaxOrderCount; i++ {
+		volume := roundVolume(weights[i]*unit, cfg.MinVolume, cfg.VolumeStep)
+		if volume <= 0 {
+			continue
+		}
+
+		price := prices[i]
+		weightedEntrySum += price * volume
+		filledVolume += volume
+
+		legs = append(legs, &database.CreateTradeRequest{
+			SignalID:       &signal.ID,
+			ParentSignalID: &signal.ID,
+			TradeType:      "dca_leg",
+			Symbol:         signal.Symbol,
+			OrderType:      "limit",
+			Direction:      signal.SignalType,
+			Volume:         volume,
+			EntryPrice:     &price,
+		})
+	}
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("dca: signal %d produced no legs at or above min volume %f", signal.ID, cfg.MinVolume)
+	}
+
+	avgEntry := weightedEntrySum / filledVolume
+	tp := weightedTakeProfit(avgEntry, cfg.TakeProfitRatio, long)
+	for _, leg := range legs {
+		leg.TakeProfit = &tp
+	}
+
+	return legs, nil
+}
+
+// weightedTakeProfit computes the shared exit price so that closing the
+// full averaged position at that price yields ratio return on avgEntry.
+func weightedTakeProfit(avgEntry, ratio float64, long bool) float64 {
+	if long {
+		return avgEntry * (1 + ratio)
+	}
+	return avgEntry * (1 - ratio)
+}
+
+// roundVolume floors v to the nearest multiple of step (if step > 0),
+// zeroing it out if the result falls below min.
+func roundVolume(v, min, step float64) float64 {
+	if step > 0 {
+		v = math.Floor(v/step) * step
+	}
+	if v < min {
+		return 0
+	}
+	return v
+}